@@ -0,0 +1,123 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// awaitPollInterval is the interval at which AwaitResult polls for new blocks in case the node
+// does not support block subscriptions.
+var awaitPollInterval = time.Second
+
+// findTransactionIndex looks for a transaction with the given hash in the given round and returns
+// its index in the block.
+func findTransactionIndex(ctx context.Context, rc RuntimeClient, txHash hash.Hash, round uint64) (uint32, bool, error) {
+	txs, err := rc.GetTransactions(ctx, round)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+	}
+	for idx, tx := range txs {
+		if h := tx.Hash(); h.Equal(&txHash) {
+			return uint32(idx), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// inclusionScanner checks each round for the awaited transaction exactly once.
+type inclusionScanner struct {
+	rc        RuntimeClient
+	txHash    hash.Hash
+	nextRound uint64
+}
+
+// scan checks all not yet checked rounds up to and including the given round. It returns the
+// round and index of the transaction and true if the transaction has been found.
+func (s *inclusionScanner) scan(ctx context.Context, toRound uint64) (uint64, uint32, bool, error) {
+	for ; s.nextRound <= toRound; s.nextRound++ {
+		idx, found, err := findTransactionIndex(ctx, s.rc, s.txHash, s.nextRound)
+		if err != nil || found {
+			return s.nextRound, idx, found, err
+		}
+	}
+	return 0, 0, false, nil
+}
+
+// awaitInclusion waits for the transaction with the given hash to be included in a block at or
+// after fromRound and returns the round and the index of the transaction in the block.
+func awaitInclusion(ctx context.Context, rc RuntimeClient, txHash hash.Hash, fromRound uint64) (uint64, uint32, error) {
+	scanner := inclusionScanner{rc: rc, txHash: txHash, nextRound: fromRound}
+
+	// Subscribe before checking existing blocks so that no blocks are missed.
+	blkCh, sub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		return awaitInclusionPolling(ctx, rc, &scanner)
+	}
+	defer sub.Close()
+
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	if round, idx, found, err := scanner.scan(ctx, blk.Header.Round); found || err != nil {
+		return round, idx, err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return 0, 0, ctx.Err()
+		case annBlk, ok := <-blkCh:
+			if !ok {
+				// The subscription has been terminated, continue by polling.
+				return awaitInclusionPolling(ctx, rc, &scanner)
+			}
+			if round, idx, found, err := scanner.scan(ctx, annBlk.Block.Header.Round); found || err != nil {
+				return round, idx, err
+			}
+		}
+	}
+}
+
+func awaitInclusionPolling(ctx context.Context, rc RuntimeClient, scanner *inclusionScanner) (uint64, uint32, error) {
+	ticker := time.NewTicker(awaitPollInterval)
+	defer ticker.Stop()
+
+	for {
+		blk, err := rc.GetBlock(ctx, RoundLatest)
+		if err != nil {
+			return 0, 0, fmt.Errorf("failed to fetch latest block: %w", err)
+		}
+		if round, idx, found, err := scanner.scan(ctx, blk.Header.Round); found || err != nil {
+			return round, idx, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, 0, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// AwaitResult waits for the transaction with the given hash to be included in a block at or after
+// fromRound and returns its call result.
+//
+// New blocks are followed via a block subscription and in case the node does not support it, by
+// periodically polling for the latest block. Use the context to bound the time spent waiting.
+func AwaitResult(ctx context.Context, rc RuntimeClient, txHash hash.Hash, fromRound uint64) (*types.CallResult, error) {
+	round, idx, err := awaitInclusion(ctx, rc, txHash, fromRound)
+	if err != nil {
+		return nil, err
+	}
+	result, err := GetTransactionResult(ctx, rc, round, idx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transaction result: %w", err)
+	}
+	return result, nil
+}
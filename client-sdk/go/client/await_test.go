@@ -0,0 +1,77 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestAwaitResultSubscription(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mt := newMemoryTransport()
+	mt.blockCh = make(chan *roothash.AnnotatedBlock)
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	other := types.UnverifiedTransaction{Body: []byte("other")}
+	awaited := types.UnverifiedTransaction{Body: []byte("awaited")}
+	mt.addBlock(1, [][]byte{cbor.Marshal(&other)}, [][]byte{cbor.Marshal(&types.CallResult{Ok: cbor.Marshal("other")})})
+
+	go func() {
+		blk := mt.addBlock(2, [][]byte{cbor.Marshal(&other), cbor.Marshal(&awaited)}, [][]byte{
+			cbor.Marshal(&types.CallResult{Ok: cbor.Marshal("other")}),
+			cbor.Marshal(&types.CallResult{Ok: cbor.Marshal("awaited")}),
+		})
+		mt.blockCh <- &roothash.AnnotatedBlock{Block: blk}
+	}()
+
+	result, err := AwaitResult(ctx, rc, awaited.Hash(), 1)
+	require.NoError(err, "AwaitResult")
+	require.True(result.IsSuccess())
+	require.EqualValues(cbor.Marshal("awaited"), result.Ok)
+}
+
+func TestAwaitResultPolling(t *testing.T) {
+	require := require.New(t)
+
+	awaitPollInterval = 10 * time.Millisecond
+	defer func() {
+		awaitPollInterval = time.Second
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	awaited := types.UnverifiedTransaction{Body: []byte("awaited")}
+	mt.addBlock(1, nil, nil)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		mt.addBlock(2, [][]byte{cbor.Marshal(&awaited)}, [][]byte{
+			cbor.Marshal(&types.CallResult{Failed: &types.FailedCallResult{Module: "test", Code: 1}}),
+		})
+	}()
+
+	result, err := AwaitResult(ctx, rc, awaited.Hash(), 1)
+	require.NoError(err, "AwaitResult")
+	require.False(result.IsSuccess())
+	require.EqualValues("test", result.Failed.Module)
+
+	// Waiting should respect the context.
+	tctx, tcancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer tcancel()
+	pending := types.UnverifiedTransaction{Body: []byte("pending")}
+	_, err = AwaitResult(tctx, rc, pending.Hash(), 1)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
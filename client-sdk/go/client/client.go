@@ -2,15 +2,20 @@ package client
 
 import (
 	"context"
+	"encoding/hex"
 	"fmt"
+	"math/rand"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
-	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
 	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
 	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
 	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
@@ -22,6 +27,17 @@ import (
 // RoundLatest is a special round number always referring to the latest round.
 const RoundLatest = coreClient.RoundLatest
 
+const (
+	// minRetryBackoff is the minimum delay between retries configured via WithRetry.
+	minRetryBackoff = time.Millisecond
+	// maxRetryBackoff is the maximum delay between retries configured via WithRetry.
+	maxRetryBackoff = time.Minute
+)
+
+// maxDebugResponseSize is the maximum number of raw response bytes included in decode errors
+// when debugging responses is enabled.
+const maxDebugResponseSize = 256
+
 // RuntimeClient is a client interface for runtimes based on the Oasis Runtime SDK.
 type RuntimeClient interface {
 	// GetInfo returns information about the runtime.
@@ -66,20 +82,91 @@ type Event struct {
 }
 
 type runtimeClient struct {
-	cs consensus.ClientBackend
-	cc coreClient.RuntimeClient
+	t Transport
 
-	runtimeID   common.Namespace
+	runtimeID common.Namespace
+
+	infoLock    sync.Mutex
 	runtimeInfo *types.RuntimeInfo
+
+	maxRetries   int
+	retryBackoff time.Duration
+	maxTxSize    int
+	concurrency  int
+
+	debugResponses bool
+}
+
+// decodeError wraps an error that occurred while deserializing the given raw response. When
+// debugging responses is enabled, a (truncated) hex dump of the raw response is included.
+func (rc *runtimeClient) decodeError(what string, raw []byte, err error) error {
+	if !rc.debugResponses {
+		return fmt.Errorf("failed to unmarshal %s: %w", what, err)
+	}
+
+	dump := raw
+	var suffix string
+	if len(dump) > maxDebugResponseSize {
+		dump = dump[:maxDebugResponseSize]
+		suffix = fmt.Sprintf("... (%d bytes total)", len(raw))
+	}
+	return fmt.Errorf("failed to unmarshal %s (raw: %s%s): %w", what, hex.EncodeToString(dump), suffix, err)
+}
+
+// encodeTx serializes the given transaction for submission, making sure it does not exceed the
+// configured maximum transaction size.
+func (rc *runtimeClient) encodeTx(tx *types.UnverifiedTransaction) ([]byte, error) {
+	data := cbor.Marshal(tx)
+	if rc.maxTxSize > 0 && len(data) > rc.maxTxSize {
+		return nil, fmt.Errorf("transaction too large (size: %d bytes, maximum: %d bytes)", len(data), rc.maxTxSize)
+	}
+	return data, nil
+}
+
+// isRetryable checks whether the given transport error indicates that the node rejected the
+// submission due to a temporary condition (e.g. it being overloaded) so that the request can be
+// safely retried.
+//
+// Errors like codes.Unavailable are not retried as they can also be reported after the node has
+// already accepted the transaction, in which case resubmitting it would fail (e.g. due to an
+// invalid nonce) and hide the original submission.
+func isRetryable(err error) bool {
+	return status.Code(err) == codes.ResourceExhausted
+}
+
+// retry invokes the given function, retrying it with a jittered exponential backoff in case it
+// fails with a retryable error and retries are configured.
+func (rc *runtimeClient) retry(ctx context.Context, fn func() error) error {
+	backoff := rc.retryBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || attempt >= rc.maxRetries || !isRetryable(err) {
+			return err
+		}
+
+		jitter := time.Duration(rand.Int63n(int64(backoff)/2 + 1)) // nolint: gosec
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff + jitter):
+		}
+		if backoff *= 2; backoff > maxRetryBackoff {
+			backoff = maxRetryBackoff
+		}
+	}
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetInfo(ctx context.Context) (*types.RuntimeInfo, error) {
+	rc.infoLock.Lock()
+	defer rc.infoLock.Unlock()
+
+	// Runtime information is immutable so it only needs to be fetched once.
 	if rc.runtimeInfo != nil {
 		return rc.runtimeInfo, nil
 	}
 
-	chainCtx, err := rc.cs.GetChainContext(ctx)
+	chainCtx, err := rc.t.GetChainContext(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch consensus layer chain context: %w", err)
 	}
@@ -93,9 +180,18 @@ func (rc *runtimeClient) GetInfo(ctx context.Context) (*types.RuntimeInfo, error
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTransaction) (cbor.RawMessage, error) {
-	raw, err := rc.cc.SubmitTx(ctx, &coreClient.SubmitTxRequest{
-		RuntimeID: rc.runtimeID,
-		Data:      cbor.Marshal(tx),
+	data, err := rc.encodeTx(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw []byte
+	err = rc.retry(ctx, func() (err error) {
+		raw, err = rc.t.SubmitTx(ctx, &coreClient.SubmitTxRequest{
+			RuntimeID: rc.runtimeID,
+			Data:      data,
+		})
+		return
 	})
 	if err != nil {
 		return nil, err
@@ -103,35 +199,42 @@ func (rc *runtimeClient) SubmitTx(ctx context.Context, tx *types.UnverifiedTrans
 
 	var result types.CallResult
 	if err = cbor.Unmarshal(raw, &result); err != nil {
-		return nil, fmt.Errorf("failed to unmarshal call result: %w", err)
+		return nil, rc.decodeError("call result", raw, err)
 	}
 	if !result.IsSuccess() {
-		return nil, result.Failed
+		return nil, callError(tx, result.Failed)
 	}
 	return result.Ok, nil
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) SubmitTxNoWait(ctx context.Context, tx *types.UnverifiedTransaction) error {
-	return rc.cc.SubmitTxNoWait(ctx, &coreClient.SubmitTxRequest{
-		RuntimeID: rc.runtimeID,
-		Data:      cbor.Marshal(tx),
+	data, err := rc.encodeTx(tx)
+	if err != nil {
+		return err
+	}
+
+	return rc.retry(ctx, func() error {
+		return rc.t.SubmitTxNoWait(ctx, &coreClient.SubmitTxRequest{
+			RuntimeID: rc.runtimeID,
+			Data:      data,
+		})
 	})
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
-	return rc.cc.WatchBlocks(ctx, rc.runtimeID)
+	return rc.t.WatchBlocks(ctx, rc.runtimeID)
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetGenesisBlock(ctx context.Context) (*block.Block, error) {
-	return rc.cc.GetGenesisBlock(ctx, rc.runtimeID)
+	return rc.t.GetGenesisBlock(ctx, rc.runtimeID)
 }
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetBlock(ctx context.Context, round uint64) (*block.Block, error) {
-	return rc.cc.GetBlock(ctx, &coreClient.GetBlockRequest{
+	return rc.t.GetBlock(ctx, &coreClient.GetBlockRequest{
 		RuntimeID: rc.runtimeID,
 		Round:     round,
 	})
@@ -145,7 +248,7 @@ func (rc *runtimeClient) GetTransactions(ctx context.Context, round uint64) ([]*
 		return nil, fmt.Errorf("failed to fetch block for round %d: %w", round, err)
 	}
 
-	rawTxs, err := rc.cc.GetTxs(ctx, &coreClient.GetTxsRequest{
+	rawTxs, err := rc.t.GetTxs(ctx, &coreClient.GetTxsRequest{
 		RuntimeID: rc.runtimeID,
 		Round:     round,
 		IORoot:    blk.Header.IORoot,
@@ -163,9 +266,43 @@ func (rc *runtimeClient) GetTransactions(ctx context.Context, round uint64) ([]*
 	return txs, nil
 }
 
+// GetTransactionResult returns the call result of the transaction at the given index in a given
+// block.
+//
+// This requires a runtime client created by New or NewWithTransport whose transport implements
+// TxResultTransport.
+func GetTransactionResult(ctx context.Context, rc RuntimeClient, round uint64, index uint32) (*types.CallResult, error) {
+	impl, ok := rc.(*runtimeClient)
+	if !ok {
+		return nil, errTxResultsNotSupported
+	}
+	return impl.getTransactionResult(ctx, round, index)
+}
+
+func (rc *runtimeClient) getTransactionResult(ctx context.Context, round uint64, index uint32) (*types.CallResult, error) {
+	trt, ok := rc.t.(TxResultTransport)
+	if !ok {
+		return nil, errTxResultsNotSupported
+	}
+	tx, err := trt.GetTx(ctx, &coreClient.GetTxRequest{
+		RuntimeID: rc.runtimeID,
+		Round:     round,
+		Index:     index,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result types.CallResult
+	if err = cbor.Unmarshal(tx.Output, &result); err != nil {
+		return nil, rc.decodeError("call result", tx.Output, err)
+	}
+	return &result, nil
+}
+
 // Implements RuntimeClient.
 func (rc *runtimeClient) GetEvents(ctx context.Context, round uint64) ([]*coreClient.Event, error) {
-	return rc.cc.GetEvents(ctx, &coreClient.GetEventsRequest{
+	return rc.t.GetEvents(ctx, &coreClient.GetEventsRequest{
 		RuntimeID: rc.runtimeID,
 		Round:     round,
 	})
@@ -173,7 +310,7 @@ func (rc *runtimeClient) GetEvents(ctx context.Context, round uint64) ([]*coreCl
 
 // Implements RuntimeClient.
 func (rc *runtimeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
-	raw, err := rc.cc.Query(ctx, &coreClient.QueryRequest{
+	raw, err := rc.t.Query(ctx, &coreClient.QueryRequest{
 		RuntimeID: rc.runtimeID,
 		Round:     round,
 		Method:    method,
@@ -183,16 +320,83 @@ func (rc *runtimeClient) Query(ctx context.Context, round uint64, method string,
 		return err
 	}
 	if err = cbor.Unmarshal(raw.Data, rsp); err != nil {
-		return fmt.Errorf("failed to unmarshal response: %w", err)
+		return rc.decodeError("response", raw.Data, err)
 	}
 	return nil
 }
 
+// Option is an option for configuring the runtime client.
+type Option func(rc *runtimeClient)
+
+// WithRetry configures the client to retry transaction submission up to maxRetries times in case
+// the node rejects it because it is overloaded. Between attempts the client waits for a jittered,
+// exponentially increasing delay starting at backoff, which is clamped to between one millisecond
+// and one minute.
+//
+// Permanent failures (e.g. invalid signatures or failed calls) and failures after which the
+// transaction may have been accepted (e.g. connection errors) are never retried.
+func WithRetry(maxRetries int, backoff time.Duration) Option {
+	switch {
+	case backoff < minRetryBackoff:
+		backoff = minRetryBackoff
+	case backoff > maxRetryBackoff:
+		backoff = maxRetryBackoff
+	}
+	return func(rc *runtimeClient) {
+		rc.maxRetries = maxRetries
+		rc.retryBackoff = backoff
+	}
+}
+
+// WithMaxTxSize configures the maximum size (in bytes) of serialized transactions. Submitting a
+// larger transaction fails client-side without contacting the node.
+//
+// This should be set to the runtime's maximum transaction size so that oversized transactions
+// (e.g. ones carrying large blobs) fail with a descriptive error.
+func WithMaxTxSize(n int) Option {
+	return func(rc *runtimeClient) {
+		rc.maxTxSize = n
+	}
+}
+
+// WithConcurrency limits the number of concurrent in-flight requests made by the client to n.
+// Requests over the limit wait until an earlier request completes, which bounds the load that
+// helpers issuing many requests in parallel (e.g. batch queries) put on the node.
+//
+// By default (or when n is zero) the number of concurrent requests is not limited. Block
+// subscriptions are not counted towards the limit.
+func WithConcurrency(n int) Option {
+	return func(rc *runtimeClient) {
+		rc.concurrency = n
+	}
+}
+
+// WithDebugResponses configures the client to include a hex dump of the raw response in errors
+// caused by responses that cannot be deserialized (e.g. due to a mismatch between the expected
+// and the actual response schema). Dumps are truncated to the first 256 bytes.
+func WithDebugResponses() Option {
+	return func(rc *runtimeClient) {
+		rc.debugResponses = true
+	}
+}
+
 // New creates a new runtime client for the specified runtime.
-func New(conn *grpc.ClientConn, runtimeID common.Namespace) RuntimeClient {
-	return &runtimeClient{
-		cs:        consensus.NewConsensusClient(conn),
-		cc:        coreClient.NewRuntimeClient(conn),
+func New(conn *grpc.ClientConn, runtimeID common.Namespace, opts ...Option) RuntimeClient {
+	return NewWithTransport(NewGRPCTransport(conn), runtimeID, opts...)
+}
+
+// NewWithTransport creates a new runtime client for the specified runtime using the given
+// transport.
+func NewWithTransport(t Transport, runtimeID common.Namespace, opts ...Option) RuntimeClient {
+	rc := &runtimeClient{
+		t:         t,
 		runtimeID: runtimeID,
 	}
+	for _, opt := range opts {
+		opt(rc)
+	}
+	if rc.concurrency > 0 {
+		rc.t = newLimitedTransport(rc.t, rc.concurrency)
+	}
+	return rc
 }
@@ -0,0 +1,307 @@
+package client
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestClientMemoryTransport(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	// Runtime information.
+	info, err := rc.GetInfo(ctx)
+	require.NoError(err, "GetInfo")
+	require.EqualValues(testRuntimeID, info.ID)
+	require.EqualValues(signature.DeriveChainContext(testRuntimeID, mt.chainContext), info.ChainContext)
+
+	// Blocks and transactions.
+	blk := block.NewGenesisBlock(testRuntimeID, 0)
+	blk.Header.Round = 1
+	mt.blocks[1] = blk
+	tx := types.UnverifiedTransaction{Body: []byte("hello world")}
+	mt.txs[1] = [][]byte{cbor.Marshal(&tx)}
+
+	b, err := rc.GetBlock(ctx, RoundLatest)
+	require.NoError(err, "GetBlock")
+	require.EqualValues(1, b.Header.Round)
+
+	txs, err := rc.GetTransactions(ctx, 1)
+	require.NoError(err, "GetTransactions")
+	require.Len(txs, 1)
+	require.EqualValues(tx.Body, txs[0].Body)
+
+	// Queries.
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		require.EqualValues(RoundLatest, round)
+		require.EqualValues("test.Query", method)
+
+		var arg uint64
+		require.NoError(cbor.Unmarshal(args, &arg))
+		return arg + 1, nil
+	}
+	var rsp uint64
+	err = rc.Query(ctx, RoundLatest, "test.Query", uint64(41), &rsp)
+	require.NoError(err, "Query")
+	require.EqualValues(42, rsp)
+
+	// Transaction submission.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return &types.CallResult{Ok: cbor.Marshal("ok")}, nil
+	}
+	raw, err := rc.SubmitTx(ctx, &tx)
+	require.NoError(err, "SubmitTx")
+	require.EqualValues(cbor.Marshal("ok"), raw)
+	require.Len(mt.submitted, 1)
+
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return &types.CallResult{Failed: &types.FailedCallResult{Module: "test", Code: 1}}, nil
+	}
+	_, err = rc.SubmitTx(ctx, &tx)
+	require.Error(err, "SubmitTx should propagate failed call results")
+	var failed *types.FailedCallResult
+	require.ErrorAs(err, &failed)
+	require.EqualValues("test", failed.Module)
+	require.EqualValues(1, failed.Code)
+}
+
+func TestClientSubmitRetry(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	tx := types.UnverifiedTransaction{Body: []byte("hello world")}
+
+	var attempts int
+	mt := newMemoryTransport()
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		attempts++
+		if attempts == 1 {
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+		return &types.CallResult{Ok: cbor.Marshal("ok")}, nil
+	}
+
+	// Without retries configured, backpressure errors are propagated.
+	rc := NewWithTransport(mt, testRuntimeID)
+	_, err := rc.SubmitTx(ctx, &tx)
+	require.Error(err, "SubmitTx should fail without retries")
+	require.EqualValues(codes.ResourceExhausted, status.Code(err))
+
+	// With retries configured, the submission should be retried.
+	attempts = 0
+	rc = NewWithTransport(mt, testRuntimeID, WithRetry(3, time.Millisecond))
+	raw, err := rc.SubmitTx(ctx, &tx)
+	require.NoError(err, "SubmitTx should succeed after a retry")
+	require.EqualValues(cbor.Marshal("ok"), raw)
+	require.EqualValues(2, attempts)
+
+	// Permanent failures should not be retried.
+	attempts = 0
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		attempts++
+		return nil, status.Error(codes.InvalidArgument, "invalid signature")
+	}
+	_, err = rc.SubmitTx(ctx, &tx)
+	require.Error(err, "SubmitTx should fail on permanent errors")
+	require.EqualValues(1, attempts)
+
+	// Errors after which the transaction may have been accepted should not be retried.
+	attempts = 0
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		attempts++
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	_, err = rc.SubmitTx(ctx, &tx)
+	require.Error(err, "SubmitTx should fail on unavailable errors")
+	require.EqualValues(codes.Unavailable, status.Code(err))
+	require.EqualValues(1, attempts)
+
+	// Out of range backoffs should be clamped.
+	rc = NewWithTransport(mt, testRuntimeID, WithRetry(3, 0))
+	require.EqualValues(minRetryBackoff, rc.(*runtimeClient).retryBackoff)
+	rc = NewWithTransport(mt, testRuntimeID, WithRetry(3, -2))
+	require.EqualValues(minRetryBackoff, rc.(*runtimeClient).retryBackoff)
+	rc = NewWithTransport(mt, testRuntimeID, WithRetry(3, time.Hour))
+	require.EqualValues(maxRetryBackoff, rc.(*runtimeClient).retryBackoff)
+}
+
+func TestClientMaxTxSize(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID, WithMaxTxSize(1024))
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test signer"))
+	tx := types.NewTransaction(nil, "hello.World", make([]byte, 4096))
+	tx.AppendAuthSignature(signer.Public(), 0)
+	require.Greater(types.EstimateTxSize(tx), 1024)
+
+	ts := tx.PrepareForSigning()
+	err := ts.AppendSign(signature.Context("test"), signer)
+	require.NoError(err, "AppendSign")
+
+	_, err = rc.SubmitTx(ctx, ts.UnverifiedTransaction())
+	require.Error(err, "SubmitTx should fail for oversized transactions")
+	require.Contains(err.Error(), "transaction too large")
+	err = rc.SubmitTxNoWait(ctx, ts.UnverifiedTransaction())
+	require.Error(err, "SubmitTxNoWait should fail for oversized transactions")
+	require.Empty(mt.submitted, "oversized transactions should not be sent to the node")
+
+	// Small transactions should go through.
+	small := types.UnverifiedTransaction{Body: []byte("hello world")}
+	_, err = rc.SubmitTx(ctx, &small)
+	require.NoError(err, "SubmitTx")
+	require.Len(mt.submitted, 1)
+}
+
+func TestClientRuntimeInfoCached(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	info1, err := rc.GetInfo(ctx)
+	require.NoError(err, "GetInfo")
+	info2, err := rc.GetInfo(ctx)
+	require.NoError(err, "GetInfo")
+	require.EqualValues(info1, info2)
+
+	// Signing should reuse the cached chain context.
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test signer"))
+	tb := NewTransactionBuilder(rc, "test.Method", nil).
+		AppendAuthSignature(signer.Public(), 0)
+	err = tb.AppendSign(ctx, signer)
+	require.NoError(err, "AppendSign")
+
+	require.EqualValues(1, mt.chainContextCalls, "chain context should only be fetched once")
+}
+
+func TestClientMethodNotSupported(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		var body types.Transaction
+		require.NoError(cbor.Unmarshal(tx.Body, &body))
+		return &types.CallResult{Failed: &types.FailedCallResult{
+			Module:  "core",
+			Code:    3,
+			Message: "invalid method: " + body.Call.Method,
+		}}, nil
+	}
+
+	// Methods of modules not included in the runtime.
+	tx := types.NewTransaction(nil, "evm.Call", nil)
+	utx := types.UnverifiedTransaction{Body: cbor.Marshal(tx)}
+	_, err := rc.SubmitTx(ctx, &utx)
+	require.Error(err, "SubmitTx should fail for unsupported methods")
+	var notSupported *ErrMethodNotSupported
+	require.ErrorAs(err, &notSupported)
+	require.EqualValues("evm.Call", notSupported.Method)
+	require.Contains(err.Error(), "method 'evm.Call' not supported")
+
+	// The failed call result should still be available.
+	var failed *types.FailedCallResult
+	require.ErrorAs(err, &failed)
+	require.EqualValues(3, failed.Code)
+
+	// Unknown methods of modules included in the runtime should not be reported as unsupported
+	// modules.
+	tx = types.NewTransaction(nil, "accounts.Foo", nil)
+	utx = types.UnverifiedTransaction{Body: cbor.Marshal(tx)}
+	_, err = rc.SubmitTx(ctx, &utx)
+	require.ErrorAs(err, &notSupported)
+	require.EqualValues("accounts.Foo", notSupported.Method)
+	require.Contains(err.Error(), "method 'accounts.Foo' not supported")
+	require.NotContains(err.Error(), "module")
+
+	// Other failures should be propagated unchanged.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return &types.CallResult{Failed: &types.FailedCallResult{Module: "accounts", Code: 2}}, nil
+	}
+	_, err = rc.SubmitTx(ctx, &utx)
+	require.Error(err, "SubmitTx should propagate failed call results")
+	require.False(errors.As(err, &notSupported), "other failures should not be reported as unsupported methods")
+}
+
+func TestClientDebugResponses(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		return "unexpected", nil
+	}
+	raw := hex.EncodeToString(cbor.Marshal("unexpected"))
+
+	// By default the raw response should not be included.
+	rc := NewWithTransport(mt, testRuntimeID)
+	var rsp uint64
+	err := rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.Error(err, "Query should fail for mismatched responses")
+	require.NotContains(err.Error(), raw)
+
+	rc = NewWithTransport(mt, testRuntimeID, WithDebugResponses())
+	err = rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.Error(err, "Query should fail for mismatched responses")
+	require.Contains(err.Error(), raw, "error should include the raw response")
+
+	// Large responses should be truncated.
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		return make([]byte, 1024), nil
+	}
+	err = rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp)
+	require.Error(err, "Query should fail for mismatched responses")
+	require.Contains(err.Error(), "(1027 bytes total)")
+}
+
+func TestClientGetTransactionResult(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	result := types.CallResult{Ok: cbor.Marshal("ok")}
+	mt.addBlock(1, [][]byte{[]byte("tx")}, [][]byte{cbor.Marshal(&result)})
+
+	for _, rc := range []RuntimeClient{
+		NewWithTransport(mt, testRuntimeID),
+		NewWithTransport(mt, testRuntimeID, WithConcurrency(1)),
+	} {
+		res, err := GetTransactionResult(ctx, rc, 1, 0)
+		require.NoError(err, "GetTransactionResult")
+		require.EqualValues(&result, res)
+	}
+
+	// Transports that do not support fetching transaction results should be rejected.
+	for _, rc := range []RuntimeClient{
+		NewWithTransport(struct{ Transport }{mt}, testRuntimeID),
+		NewWithTransport(struct{ Transport }{mt}, testRuntimeID, WithConcurrency(1)),
+	} {
+		_, err := GetTransactionResult(ctx, rc, 1, 0)
+		require.Error(err, "GetTransactionResult should fail without transport support")
+		require.Contains(err.Error(), "not supported")
+	}
+}
@@ -0,0 +1,362 @@
+// Package clienttest provides an in-memory runtime fixture for testing code built on top of the
+// runtime client without a running node.
+package clienttest
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// defaultGas is the gas returned by the core.EstimateGas query.
+const defaultGas = 1000
+
+// TestChainContext is the consensus chain context used by fixtures.
+const TestChainContext = "0000000000000000000000000000000000000000000000000000000000000001"
+
+// TxHandler handles a transaction calling a given method by mutating the state. It returns the
+// method call result which must be serializable.
+//
+// To report a failed call the handler should return a *types.FailedCallResult error.
+type TxHandler func(state *State, caller types.Address, tx *types.Transaction) (interface{}, error)
+
+// QueryHandler handles a query of a given method. It returns the query response which must be
+// serializable.
+type QueryHandler func(state *State, args cbor.RawMessage) (interface{}, error)
+
+// State is the in-memory runtime state of a fixture.
+type State struct {
+	balances map[types.Address]map[types.Denomination]quantity.Quantity
+	nonces   map[types.Address]uint64
+}
+
+// Balance returns the balance of the given account.
+func (s *State) Balance(address types.Address, denomination types.Denomination) quantity.Quantity {
+	balance := s.balances[address][denomination]
+	return *balance.Clone()
+}
+
+// Nonce returns the nonce of the given account.
+func (s *State) Nonce(address types.Address) uint64 {
+	return s.nonces[address]
+}
+
+// SetBalance sets the balance of the given account.
+func (s *State) SetBalance(address types.Address, amount types.BaseUnits) {
+	if s.balances[address] == nil {
+		s.balances[address] = make(map[types.Denomination]quantity.Quantity)
+	}
+	s.balances[address][amount.Denomination] = *amount.Amount.Clone()
+}
+
+// Transfer transfers the given amount between two accounts.
+func (s *State) Transfer(from, to types.Address, amount types.BaseUnits) error {
+	fromBalance := s.Balance(from, amount.Denomination)
+	if err := fromBalance.Sub(&amount.Amount); err != nil {
+		return &types.FailedCallResult{
+			Module:  accounts.ModuleName,
+			Code:    accounts.ErrInsufficientBalanceCode,
+			Message: "insufficient balance",
+		}
+	}
+	s.SetBalance(from, types.NewBaseUnits(fromBalance, amount.Denomination))
+
+	toBalance := s.Balance(to, amount.Denomination)
+	if err := toBalance.Add(&amount.Amount); err != nil {
+		return err
+	}
+	s.SetBalance(to, types.NewBaseUnits(toBalance, amount.Denomination))
+	return nil
+}
+
+// Fixture is an in-memory runtime which can be used instead of a node in tests.
+//
+// Each submitted transaction is executed against the in-memory state using the handler
+// registered for its method and included in a new block, which is announced to any block
+// subscribers. Accounts transfers and the nonce, balances and gas estimation queries are supported
+// out of the box, other methods can be added via RegisterTx and RegisterQuery.
+type Fixture struct {
+	lock sync.Mutex
+
+	runtimeID common.Namespace
+	state     State
+
+	txHandlers    map[string]TxHandler
+	queryHandlers map[string]QueryHandler
+
+	blocks   []*block.Block
+	txs      map[uint64][][]byte
+	results  map[uint64][][]byte
+	blockNtf *pubsub.Broker
+}
+
+// RuntimeID returns the runtime identifier of the fixture.
+func (f *Fixture) RuntimeID() common.Namespace {
+	return f.runtimeID
+}
+
+// ChainContext returns the chain domain separation context that transactions should be signed
+// with.
+func (f *Fixture) ChainContext() signature.Context {
+	return signature.DeriveChainContext(f.runtimeID, TestChainContext)
+}
+
+// Client returns a runtime client backed by the fixture.
+func (f *Fixture) Client(opts ...client.Option) client.RuntimeClient {
+	return client.NewWithTransport(f, f.runtimeID, opts...)
+}
+
+// SetBalance sets the balance of the given account.
+func (f *Fixture) SetBalance(address types.Address, amount types.BaseUnits) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.state.SetBalance(address, amount)
+}
+
+// Balance returns the balance of the given account.
+func (f *Fixture) Balance(address types.Address, denomination types.Denomination) quantity.Quantity {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.state.Balance(address, denomination)
+}
+
+// RegisterTx registers a handler for transactions calling the given method.
+func (f *Fixture) RegisterTx(method string, handler TxHandler) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.txHandlers[method] = handler
+}
+
+// RegisterQuery registers a handler for the given query method.
+func (f *Fixture) RegisterQuery(method string, handler QueryHandler) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.queryHandlers[method] = handler
+}
+
+// Implements client.Transport.
+func (f *Fixture) GetChainContext(ctx context.Context) (string, error) {
+	return TestChainContext, nil
+}
+
+func (f *Fixture) execute(data []byte) (*types.CallResult, error) {
+	var utx types.UnverifiedTransaction
+	if err := cbor.Unmarshal(data, &utx); err != nil {
+		return nil, fmt.Errorf("malformed transaction: %w", err)
+	}
+	tx, err := utx.Verify(f.ChainContext())
+	if err != nil {
+		return nil, err
+	}
+	caller, err := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+	if err != nil {
+		return nil, err
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	handler, ok := f.txHandlers[tx.Call.Method]
+	if !ok {
+		return &types.CallResult{Failed: &types.FailedCallResult{
+			Module:  core.ModuleName,
+			Code:    core.ErrInvalidMethodCode,
+			Message: fmt.Sprintf("invalid method: %s", tx.Call.Method),
+		}}, nil
+	}
+	for _, si := range tx.AuthInfo.SignerInfo {
+		addr, _ := si.AddressSpec.Address()
+		if si.Nonce != f.state.nonces[addr] {
+			return nil, fmt.Errorf("invalid nonce for %s (expected: %d got: %d)", addr, f.state.nonces[addr], si.Nonce)
+		}
+	}
+	for _, si := range tx.AuthInfo.SignerInfo {
+		addr, _ := si.AddressSpec.Address()
+		f.state.nonces[addr]++
+	}
+
+	// Include the transaction in a new block.
+	round := uint64(len(f.blocks))
+	blk := block.NewGenesisBlock(f.runtimeID, f.blocks[round-1].Header.Timestamp+1)
+	blk.Header.Round = round
+	f.blocks = append(f.blocks, blk)
+	f.txs[round] = [][]byte{data}
+
+	var result types.CallResult
+	rsp, err := handler(&f.state, caller, tx)
+	switch failed, ok := err.(*types.FailedCallResult); {
+	case err == nil:
+		result.Ok = cbor.Marshal(rsp)
+	case ok:
+		result.Failed = failed
+	default:
+		return nil, err
+	}
+	f.results[round] = [][]byte{cbor.Marshal(&result)}
+	f.blockNtf.Broadcast(&roothash.AnnotatedBlock{Height: int64(round), Block: blk})
+	return &result, nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) SubmitTx(ctx context.Context, request *coreClient.SubmitTxRequest) ([]byte, error) {
+	result, err := f.execute(request.Data)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(result), nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) SubmitTxNoWait(ctx context.Context, request *coreClient.SubmitTxRequest) error {
+	_, err := f.execute(request.Data)
+	return err
+}
+
+// Implements client.Transport.
+func (f *Fixture) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.blocks[0], nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) GetBlock(ctx context.Context, request *coreClient.GetBlockRequest) (*block.Block, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	round := request.Round
+	if round == client.RoundLatest {
+		round = uint64(len(f.blocks) - 1)
+	}
+	if round >= uint64(len(f.blocks)) {
+		return nil, fmt.Errorf("block not found")
+	}
+	return f.blocks[round], nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) GetTxs(ctx context.Context, request *coreClient.GetTxsRequest) ([][]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.txs[request.Round], nil
+}
+
+// Implements client.TxResultTransport.
+func (f *Fixture) GetTx(ctx context.Context, request *coreClient.GetTxRequest) (*coreClient.TxResult, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	txs := f.txs[request.Round]
+	if request.Round >= uint64(len(f.blocks)) || request.Index >= uint32(len(txs)) {
+		return nil, fmt.Errorf("transaction not found")
+	}
+	return &coreClient.TxResult{
+		Block:  f.blocks[request.Round],
+		Index:  request.Index,
+		Input:  txs[request.Index],
+		Output: f.results[request.Round][request.Index],
+	}, nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) GetEvents(ctx context.Context, request *coreClient.GetEventsRequest) ([]*coreClient.Event, error) {
+	return nil, nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) Query(ctx context.Context, request *coreClient.QueryRequest) (*coreClient.QueryResponse, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	handler, ok := f.queryHandlers[request.Method]
+	if !ok {
+		return nil, fmt.Errorf("invalid method: %s", request.Method)
+	}
+	rsp, err := handler(&f.state, request.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &coreClient.QueryResponse{Data: cbor.Marshal(rsp)}, nil
+}
+
+// Implements client.Transport.
+func (f *Fixture) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	typedCh := make(chan *roothash.AnnotatedBlock)
+	sub := f.blockNtf.Subscribe()
+	sub.Unwrap(typedCh)
+	return typedCh, sub, nil
+}
+
+func handleTransfer(state *State, caller types.Address, tx *types.Transaction) (interface{}, error) {
+	var body accounts.Transfer
+	if err := types.DecodeBodyInto(tx.Call.Body, &body); err != nil {
+		return nil, err
+	}
+	if err := state.Transfer(caller, body.To, body.Amount); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func queryNonce(state *State, args cbor.RawMessage) (interface{}, error) {
+	var query accounts.NonceQuery
+	if err := cbor.Unmarshal(args, &query); err != nil {
+		return nil, err
+	}
+	return state.Nonce(query.Address), nil
+}
+
+func queryBalances(state *State, args cbor.RawMessage) (interface{}, error) {
+	var query accounts.BalancesQuery
+	if err := cbor.Unmarshal(args, &query); err != nil {
+		return nil, err
+	}
+	balances := accounts.AccountBalances{
+		Balances: make(map[types.Denomination]types.Quantity),
+	}
+	for denomination := range state.balances[query.Address] {
+		balances.Balances[denomination] = state.Balance(query.Address, denomination)
+	}
+	return &balances, nil
+}
+
+func queryEstimateGas(state *State, args cbor.RawMessage) (interface{}, error) {
+	return uint64(defaultGas), nil
+}
+
+// NewFixture creates a new runtime fixture with the given runtime identifier.
+func NewFixture(runtimeID common.Namespace) *Fixture {
+	return &Fixture{
+		runtimeID: runtimeID,
+		state: State{
+			balances: make(map[types.Address]map[types.Denomination]quantity.Quantity),
+			nonces:   make(map[types.Address]uint64),
+		},
+		txHandlers: map[string]TxHandler{
+			"accounts.Transfer": handleTransfer,
+		},
+		queryHandlers: map[string]QueryHandler{
+			"accounts.Nonce":    queryNonce,
+			"accounts.Balances": queryBalances,
+			"core.EstimateGas":  queryEstimateGas,
+		},
+		blocks:   []*block.Block{block.NewGenesisBlock(runtimeID, 0)},
+		txs:      make(map[uint64][][]byte),
+		results:  make(map[uint64][][]byte),
+		blockNtf: pubsub.NewBroker(false),
+	}
+}
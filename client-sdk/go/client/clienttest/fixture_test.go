@@ -0,0 +1,101 @@
+package clienttest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var testRuntimeID = common.NewTestNamespaceFromSeed([]byte("oasis-sdk/clienttest: test runtime"), 0)
+
+func nativeAmount(amount uint64) types.BaseUnits {
+	return types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)
+}
+
+func TestFixtureTransfer(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	f := NewFixture(testRuntimeID)
+	f.SetBalance(sdkTesting.Alice.Address, nativeAmount(1000))
+	rc := f.Client()
+	ac := accounts.NewV1(rc)
+
+	tb := ac.Transfer(sdkTesting.Bob.Address, nativeAmount(300)).
+		SetFeeGas(1000).
+		AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 0)
+	err := tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	res, err := tb.Execute(ctx)
+	require.NoError(err, "Execute")
+	require.EqualValues(1, res.Round)
+
+	balances, err := ac.Balances(ctx, client.RoundLatest, sdkTesting.Alice.Address)
+	require.NoError(err, "Balances")
+	require.EqualValues(*quantity.NewFromUint64(700), balances.Balances[types.NativeDenomination])
+	bobBalance := f.Balance(sdkTesting.Bob.Address, types.NativeDenomination)
+	require.EqualValues(*quantity.NewFromUint64(300), bobBalance)
+
+	nonce, err := ac.Nonce(ctx, client.RoundLatest, sdkTesting.Alice.Address)
+	require.NoError(err, "Nonce")
+	require.EqualValues(1, nonce)
+
+	// Transferring more than the balance should fail.
+	tb = ac.Transfer(sdkTesting.Bob.Address, nativeAmount(1000)).
+		SetFeeGas(1000).
+		AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 1)
+	err = tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	err = tb.SubmitTx(ctx, nil)
+	require.Error(err, "SubmitTx should fail with insufficient balance")
+	var failed *types.FailedCallResult
+	require.True(errors.As(err, &failed))
+	require.EqualValues(accounts.ModuleName, failed.Module)
+	require.EqualValues(accounts.ErrInsufficientBalanceCode, failed.Code)
+	aliceBalance := f.Balance(sdkTesting.Alice.Address, types.NativeDenomination)
+	require.EqualValues(*quantity.NewFromUint64(700), aliceBalance)
+
+	// Transfers to self should not change the balance.
+	tb = ac.Transfer(sdkTesting.Alice.Address, nativeAmount(100)).
+		SetFeeGas(1000).
+		AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 2)
+	err = tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	err = tb.SubmitTx(ctx, nil)
+	require.NoError(err, "SubmitTx")
+	aliceBalance = f.Balance(sdkTesting.Alice.Address, types.NativeDenomination)
+	require.EqualValues(*quantity.NewFromUint64(700), aliceBalance)
+}
+
+func TestFixtureInvalidMethod(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	f := NewFixture(testRuntimeID)
+	rc := f.Client()
+
+	// Calls to unknown methods should be reported the same way the runtime client detects them.
+	tb := client.NewTransactionBuilder(rc, "evm.Call", nil).
+		SetFeeGas(1000).
+		AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 0)
+	err := tb.AppendSign(ctx, sdkTesting.Alice.Signer)
+	require.NoError(err, "AppendSign")
+	err = tb.SubmitTx(ctx, nil)
+	require.Error(err, "SubmitTx should fail for unknown methods")
+	var notSupported *client.ErrMethodNotSupported
+	require.True(errors.As(err, &notSupported))
+	require.EqualValues("evm.Call", notSupported.Method)
+	require.EqualValues(core.ModuleName, notSupported.Cause.Module)
+	require.EqualValues(core.ErrInvalidMethodCode, notSupported.Cause.Code)
+}
@@ -0,0 +1,143 @@
+package client
+
+import (
+	"context"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// limitedTransport is a transport that limits the number of concurrent in-flight requests to the
+// underlying transport.
+type limitedTransport struct {
+	t   Transport
+	sem chan struct{}
+}
+
+func (lt *limitedTransport) acquire(ctx context.Context) error {
+	select {
+	case lt.sem <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (lt *limitedTransport) release() {
+	<-lt.sem
+}
+
+// Implements Transport.
+func (lt *limitedTransport) GetChainContext(ctx context.Context) (string, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return "", err
+	}
+	defer lt.release()
+	return lt.t.GetChainContext(ctx)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) SubmitTx(ctx context.Context, request *coreClient.SubmitTxRequest) ([]byte, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.SubmitTx(ctx, request)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) SubmitTxNoWait(ctx context.Context, request *coreClient.SubmitTxRequest) error {
+	if err := lt.acquire(ctx); err != nil {
+		return err
+	}
+	defer lt.release()
+	return lt.t.SubmitTxNoWait(ctx, request)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.GetGenesisBlock(ctx, runtimeID)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) GetBlock(ctx context.Context, request *coreClient.GetBlockRequest) (*block.Block, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.GetBlock(ctx, request)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) GetTxs(ctx context.Context, request *coreClient.GetTxsRequest) ([][]byte, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.GetTxs(ctx, request)
+}
+
+// Implements TxResultTransport.
+func (lt *limitedTransport) GetTx(ctx context.Context, request *coreClient.GetTxRequest) (*coreClient.TxResult, error) {
+	trt, ok := lt.t.(TxResultTransport)
+	if !ok {
+		return nil, errTxResultsNotSupported
+	}
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return trt.GetTx(ctx, request)
+}
+
+// Implements RegistryTransport.
+func (lt *limitedTransport) GetRuntime(ctx context.Context, query *registry.NamespaceQuery) (*registry.Runtime, error) {
+	rt, ok := lt.t.(RegistryTransport)
+	if !ok {
+		return nil, errRegistryNotSupported
+	}
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return rt.GetRuntime(ctx, query)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) GetEvents(ctx context.Context, request *coreClient.GetEventsRequest) ([]*coreClient.Event, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.GetEvents(ctx, request)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) Query(ctx context.Context, request *coreClient.QueryRequest) (*coreClient.QueryResponse, error) {
+	if err := lt.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer lt.release()
+	return lt.t.Query(ctx, request)
+}
+
+// Implements Transport.
+func (lt *limitedTransport) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	// Subscriptions are long-lived so they are not counted towards the limit.
+	return lt.t.WatchBlocks(ctx, runtimeID)
+}
+
+func newLimitedTransport(t Transport, n int) Transport {
+	return &limitedTransport{
+		t:   t,
+		sem: make(chan struct{}, n),
+	}
+}
@@ -0,0 +1,65 @@
+package client
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+func TestClientConcurrency(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+
+	var active, maxActive int32
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		n := atomic.AddInt32(&active, 1)
+		defer atomic.AddInt32(&active, -1)
+		for {
+			m := atomic.LoadInt32(&maxActive)
+			if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		return uint64(42), nil
+	}
+
+	rc := NewWithTransport(mt, testRuntimeID, WithConcurrency(3))
+
+	var (
+		wg     sync.WaitGroup
+		failed int32
+	)
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var rsp uint64
+			if err := rc.Query(ctx, RoundLatest, "test.Query", nil, &rsp); err != nil {
+				atomic.AddInt32(&failed, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	require.Zero(failed, "all queries should succeed")
+
+	require.EqualValues(3, atomic.LoadInt32(&maxActive), "at most 3 requests should be in flight")
+
+	// Waiting for a free slot should respect the context.
+	lt := newLimitedTransport(mt, 1).(*limitedTransport)
+	require.NoError(lt.acquire(ctx))
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err := lt.GetChainContext(cctx)
+	require.ErrorIs(err, context.Canceled)
+	lt.release()
+}
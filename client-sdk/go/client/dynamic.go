@@ -0,0 +1,45 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// QueryDynamic makes a runtime-specific query and decodes the response into a generic value
+// without requiring a response type.
+//
+// Maps are decoded as map[string]interface{} (non-string keys are formatted using fmt), arrays
+// as []interface{} and byte strings as []byte, so the result is suitable for printing as JSON.
+// This is mostly useful for exploring and debugging unfamiliar queries.
+func QueryDynamic(ctx context.Context, rc RuntimeClient, round uint64, method string, args interface{}) (interface{}, error) {
+	var rsp interface{}
+	if err := rc.Query(ctx, round, method, args, &rsp); err != nil {
+		return nil, err
+	}
+	return normalizeDynamic(rsp), nil
+}
+
+func normalizeDynamic(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[interface{}]interface{}:
+		m := make(map[string]interface{}, len(v))
+		for k, val := range v {
+			var key string
+			switch k := k.(type) {
+			case string:
+				key = k
+			default:
+				key = fmt.Sprintf("%v", k)
+			}
+			m[key] = normalizeDynamic(val)
+		}
+		return m
+	case []interface{}:
+		for i, val := range v {
+			v[i] = normalizeDynamic(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
@@ -0,0 +1,46 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+func TestQueryDynamic(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		switch method {
+		case "test.Code":
+			return []byte{0x60, 0x80, 0x60, 0x40}, nil
+		default:
+			return map[string]interface{}{
+				"balances": map[string]uint64{"": 100, "TEST": 5},
+				"list":     []interface{}{uint64(1), "two", map[uint64]string{3: "three"}},
+			}, nil
+		}
+	}
+
+	rsp, err := QueryDynamic(ctx, rc, RoundLatest, "test.Code", nil)
+	require.NoError(err, "QueryDynamic")
+	require.IsType([]byte{}, rsp)
+	require.EqualValues([]byte{0x60, 0x80, 0x60, 0x40}, rsp)
+
+	rsp, err = QueryDynamic(ctx, rc, RoundLatest, "test.Map", nil)
+	require.NoError(err, "QueryDynamic")
+	m, ok := rsp.(map[string]interface{})
+	require.True(ok, "maps should be decoded with string keys")
+	require.EqualValues(map[string]interface{}{"": uint64(100), "TEST": uint64(5)}, m["balances"])
+
+	js, err := json.Marshal(rsp)
+	require.NoError(err, "result should be serializable as JSON")
+	require.JSONEq(`{"balances":{"":100,"TEST":5},"list":[1,"two",{"3":"three"}]}`, string(js))
+}
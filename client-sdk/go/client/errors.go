@@ -0,0 +1,66 @@
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// These mirror core.ModuleName and core.ErrInvalidMethodCode which can't be used here as the core
+// module package depends on the client.
+const (
+	// coreModuleName is the name of the core module which reports dispatch errors.
+	coreModuleName = "core"
+	// coreErrInvalidMethod is the core module error code for an unknown method.
+	coreErrInvalidMethod = 3
+)
+
+// errTxResultsNotSupported is the error returned when fetching transaction results is not
+// supported by the runtime client or its transport.
+var errTxResultsNotSupported = errors.New("fetching transaction results not supported by the transport")
+
+// errRegistryNotSupported is the error returned when querying the registry is not supported by the
+// runtime client or its transport.
+var errRegistryNotSupported = errors.New("querying the registry not supported by the transport")
+
+// ErrMethodNotSupported is the error returned when a transaction calls a method that the runtime
+// does not know about. This is either because the runtime does not include the method's module
+// (e.g. calling evm.Call on a runtime without the EVM module) or because the module does not have
+// such a method, the runtime reports both cases the same way.
+type ErrMethodNotSupported struct {
+	// Method is the name of the called method.
+	Method string
+
+	// Cause is the failed call result returned by the runtime.
+	Cause *types.FailedCallResult
+}
+
+// Error implements error.
+func (e *ErrMethodNotSupported) Error() string {
+	return fmt.Sprintf("method '%s' not supported by the runtime", e.Method)
+}
+
+// Unwrap returns the failed call result returned by the runtime.
+func (e *ErrMethodNotSupported) Unwrap() error {
+	return e.Cause
+}
+
+// callError converts a failed call result of the given transaction into an error, detecting calls
+// to methods not supported by the runtime.
+func callError(tx *types.UnverifiedTransaction, failed *types.FailedCallResult) error {
+	if failed.Module != coreModuleName || failed.Code != coreErrInvalidMethod {
+		return failed
+	}
+
+	var body types.Transaction
+	if err := cbor.Unmarshal(tx.Body, &body); err != nil {
+		return failed
+	}
+	return &ErrMethodNotSupported{
+		Method: body.Call.Method,
+		Cause:  failed,
+	}
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ExecutionResult is the result of executing a transaction.
+type ExecutionResult struct {
+	// TxHash is the hash of the executed transaction.
+	TxHash hash.Hash
+	// Round is the round in which the transaction has been included.
+	Round uint64
+	// Result is the serialized result of the successful method call.
+	Result cbor.RawMessage
+	// GasUsed is the gas used by the transaction. It is only populated when the WithGasUsed
+	// option is given.
+	GasUsed uint64
+	// Events are the events emitted by the transaction.
+	Events []*coreClient.Event
+}
+
+type executeOptions struct {
+	inclusionTimeout time.Duration
+	gasUsed          bool
+}
+
+// ExecuteOption is an option for configuring Execute.
+type ExecuteOption func(o *executeOptions)
+
+// WithInclusionTimeout bounds the time spent waiting for the transaction to be executed. In case
+// the transaction is not executed in time, Execute fails with an error wrapping
+// context.DeadlineExceeded.
+func WithInclusionTimeout(timeout time.Duration) ExecuteOption {
+	return func(o *executeOptions) {
+		o.inclusionTimeout = timeout
+	}
+}
+
+// WithGasUsed configures Execute to also report the gas used by the transaction. The runtime does
+// not include it in the call result so it is obtained via the core.EstimateGas query against the
+// state preceding the round in which the transaction has been included, which makes the runtime
+// report the gas used by the call.
+func WithGasUsed() ExecuteOption {
+	return func(o *executeOptions) {
+		o.gasUsed = true
+	}
+}
+
+// Execute submits the given transaction, waits for it to be executed and gathers the round in
+// which the transaction has been included together with all the events that it emitted.
+//
+// In case the call fails, the failed call result is returned as an error. Use the context or the
+// WithInclusionTimeout option to bound the time spent waiting for inclusion.
+func Execute(ctx context.Context, rc RuntimeClient, tx *types.UnverifiedTransaction, opts ...ExecuteOption) (*ExecutionResult, error) {
+	var o executeOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	startRound := blk.Header.Round
+
+	submitCtx := ctx
+	if o.inclusionTimeout > 0 {
+		var cancel context.CancelFunc
+		submitCtx, cancel = context.WithTimeout(ctx, o.inclusionTimeout)
+		defer cancel()
+	}
+	result, err := rc.SubmitTx(submitCtx, tx)
+	switch {
+	case err == nil:
+	case submitCtx.Err() != nil && ctx.Err() == nil:
+		return nil, fmt.Errorf("transaction not executed within %s: %w", o.inclusionTimeout, submitCtx.Err())
+	default:
+		return nil, err
+	}
+
+	blk, err = rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	txHash := tx.Hash()
+	round, found, err := FindTransaction(ctx, rc, txHash, startRound+1, blk.Header.Round)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, fmt.Errorf("executed transaction %s not found in rounds %d-%d", txHash, startRound+1, blk.Header.Round)
+	}
+
+	allEvents, err := rc.GetEvents(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+	}
+	var events []*coreClient.Event
+	for _, ev := range allEvents {
+		if ev.TxHash.Equal(&txHash) {
+			events = append(events, ev)
+		}
+	}
+
+	var gasUsed uint64
+	if o.gasUsed {
+		var body types.Transaction
+		if err = cbor.Unmarshal(tx.Body, &body); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal transaction: %w", err)
+		}
+		if err = rc.Query(ctx, round-1, methodEstimateGas, &body, &gasUsed); err != nil {
+			return nil, fmt.Errorf("failed to determine gas used: %w", err)
+		}
+	}
+
+	return &ExecutionResult{
+		TxHash:  txHash,
+		Round:   round,
+		Result:  result,
+		GasUsed: gasUsed,
+		Events:  events,
+	}, nil
+}
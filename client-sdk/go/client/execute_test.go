@@ -0,0 +1,97 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestExecute(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	blk := block.NewGenesisBlock(testRuntimeID, 0)
+	mt.blocks[0] = blk
+
+	// Include each submitted transaction in a new block together with an event.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		txHash := tx.Hash()
+		round := mt.latestRound() + 1
+		blk := block.NewGenesisBlock(testRuntimeID, 0)
+		blk.Header.Round = round
+		mt.blocks[round] = blk
+		mt.txs[round] = [][]byte{cbor.Marshal(tx)}
+		mt.events[round] = []*coreClient.Event{
+			{Key: []byte("other"), Value: []byte("event")},
+			{Key: []byte("test"), Value: []byte("event"), TxHash: txHash},
+		}
+		return &types.CallResult{Ok: cbor.Marshal("ok")}, nil
+	}
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test signer"))
+	tb := NewTransactionBuilder(rc, "test.Method", nil).
+		AppendAuthSignature(signer.Public(), 0)
+	_, err := tb.Execute(ctx)
+	require.Error(err, "Execute should fail for unsigned transactions")
+
+	err = tb.AppendSign(ctx, signer)
+	require.NoError(err, "AppendSign")
+
+	res, err := tb.Execute(ctx)
+	require.NoError(err, "Execute")
+	require.EqualValues(1, res.Round)
+	require.EqualValues(mt.submitted[0].Hash(), res.TxHash)
+	require.EqualValues(cbor.Marshal("ok"), res.Result)
+	require.Len(res.Events, 1)
+	require.EqualValues("test", res.Events[0].Key)
+	require.Zero(res.GasUsed, "gas used should only be reported when requested")
+
+	// Gas used should be estimated against the state preceding the round of inclusion.
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		if method != methodEstimateGas {
+			return nil, fmt.Errorf("unsupported query: %s", method)
+		}
+		var tx types.Transaction
+		if err := cbor.Unmarshal(args, &tx); err != nil {
+			return nil, err
+		}
+		if tx.Call.Method != "test.Method" {
+			return nil, fmt.Errorf("unexpected method: %s", tx.Call.Method)
+		}
+		return 1000 + round, nil
+	}
+	res, err = tb.Execute(ctx, WithGasUsed(), WithInclusionTimeout(time.Second))
+	require.NoError(err, "Execute")
+	require.EqualValues(2, res.Round)
+	require.EqualValues(1001, res.GasUsed)
+
+	// Transactions not executed in time should fail.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		time.Sleep(50 * time.Millisecond)
+		return nil, context.DeadlineExceeded
+	}
+	_, err = tb.Execute(ctx, WithInclusionTimeout(10*time.Millisecond))
+	require.Error(err, "Execute should fail when the transaction is not executed in time")
+	require.ErrorIs(err, context.DeadlineExceeded)
+
+	// Failed calls should be reported as errors.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return &types.CallResult{Failed: &types.FailedCallResult{Module: "test", Code: 1}}, nil
+	}
+	_, err = tb.Execute(ctx)
+	require.Error(err, "Execute should fail for failed calls")
+}
@@ -0,0 +1,178 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// MaxAccountTxsRounds is the maximum number of rounds that can be scanned by a single
+// GetAccountTxs call.
+const MaxAccountTxsRounds = 1000
+
+// TransactionWithResults is a transaction included in a block together with the events that it
+// emitted.
+type TransactionWithResults struct {
+	// Round is the round in which the transaction has been included.
+	Round uint64
+	// TxHash is the hash of the transaction.
+	TxHash hash.Hash
+	// Tx is the deserialized transaction.
+	Tx *types.Transaction
+	// Events are the events emitted by the transaction.
+	Events []*coreClient.Event
+}
+
+// signedBy returns true if the given transaction has been signed by the given address.
+func signedBy(tx *types.Transaction, address types.Address) bool {
+	for _, si := range tx.AuthInfo.SignerInfo {
+		if signer, err := si.AddressSpec.Address(); err == nil && signer.Equal(address) {
+			return true
+		}
+	}
+	return false
+}
+
+// involves returns true if the given transaction has been signed by the given address or if its
+// call body names the given address as the recipient (e.g. an accounts.Transfer).
+func involves(tx *types.Transaction, address types.Address) bool {
+	if signedBy(tx, address) {
+		return true
+	}
+
+	var body map[string]cbor.RawMessage
+	if err := cbor.Unmarshal(tx.Call.Body, &body); err != nil {
+		return false
+	}
+	rawTo, ok := body["to"]
+	if !ok {
+		return false
+	}
+	var to types.Address
+	if err := cbor.Unmarshal(rawTo, &to); err != nil {
+		return false
+	}
+	return to.Equal(address)
+}
+
+// GetAccountTxs returns all transactions between fromRound and toRound (both inclusive) that
+// involve the given address, either as a signer or as the recipient named in the call body.
+//
+// Note that this needs to fetch and decode all transactions (and events) in the given range, so
+// it is expensive and at most MaxAccountTxsRounds rounds can be scanned in a single call. Use
+// multiple calls to page through longer histories. Malformed transactions are skipped.
+func GetAccountTxs(ctx context.Context, rc RuntimeClient, address types.Address, fromRound, toRound uint64) ([]*TransactionWithResults, error) {
+	if toRound < fromRound {
+		return nil, fmt.Errorf("invalid round range: %d-%d", fromRound, toRound)
+	}
+	if toRound-fromRound >= MaxAccountTxsRounds {
+		return nil, fmt.Errorf("round range too large (rounds: %d, maximum: %d)", toRound-fromRound+1, MaxAccountTxsRounds)
+	}
+
+	var result []*TransactionWithResults
+	for round := fromRound; round <= toRound; round++ {
+		txs, err := rc.GetTransactions(ctx, round)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+		}
+
+		var matched []*TransactionWithResults
+		for _, utx := range txs {
+			var tx types.Transaction
+			if err = cbor.Unmarshal(utx.Body, &tx); err != nil {
+				continue
+			}
+			if !involves(&tx, address) {
+				continue
+			}
+			matched = append(matched, &TransactionWithResults{
+				Round:  round,
+				TxHash: utx.Hash(),
+				Tx:     &tx,
+			})
+		}
+		if len(matched) == 0 {
+			continue
+		}
+
+		events, err := rc.GetEvents(ctx, round)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+		}
+		for _, ev := range events {
+			for _, m := range matched {
+				if ev.TxHash.Equal(&m.TxHash) {
+					m.Events = append(m.Events, ev)
+				}
+			}
+		}
+		result = append(result, matched...)
+	}
+	return result, nil
+}
+
+// GetTransaction returns the deserialized transaction with the given hash included in the given
+// round. An error is returned in case the transaction is not part of the round or is malformed.
+//
+// Note that signatures are not verified.
+func GetTransaction(ctx context.Context, rc RuntimeClient, round uint64, txHash hash.Hash) (*types.Transaction, error) {
+	txs, err := rc.GetTransactions(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+	}
+	for _, utx := range txs {
+		if h := utx.Hash(); !h.Equal(&txHash) {
+			continue
+		}
+
+		var tx types.Transaction
+		if err = cbor.Unmarshal(utx.Body, &tx); err != nil {
+			return nil, fmt.Errorf("malformed transaction %s: %w", txHash, err)
+		}
+		return &tx, nil
+	}
+	return nil, fmt.Errorf("transaction %s not found in round %d", txHash, round)
+}
+
+// GetEventsBySender returns all events emitted in the given round by transactions signed by the
+// given sender.
+//
+// Events are correlated with their originating transactions via the transaction hash, so this
+// needs to fetch and decode all transactions in the round. Malformed transactions are skipped.
+func GetEventsBySender(ctx context.Context, rc RuntimeClient, round uint64, sender types.Address) ([]*coreClient.Event, error) {
+	txs, err := rc.GetTransactions(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+	}
+
+	txHashes := make(map[hash.Hash]bool)
+	for _, utx := range txs {
+		var tx types.Transaction
+		if err = cbor.Unmarshal(utx.Body, &tx); err != nil {
+			continue
+		}
+		if signedBy(&tx, sender) {
+			txHashes[utx.Hash()] = true
+		}
+	}
+	if len(txHashes) == 0 {
+		return nil, nil
+	}
+
+	events, err := rc.GetEvents(ctx, round)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch events for round %d: %w", round, err)
+	}
+	var result []*coreClient.Event
+	for _, ev := range events {
+		if txHashes[ev.TxHash] {
+			result = append(result, ev)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,157 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestGetAccountTxs(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	type transfer struct {
+		To     types.Address   `json:"to"`
+		Amount types.BaseUnits `json:"amount"`
+	}
+	var (
+		alice   = ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test alice"))
+		bob     = ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test bob"))
+		charlie = ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test charlie"))
+	)
+	newTransfer := func(from, to signature.Signer) *types.UnverifiedTransaction {
+		tx := types.NewTransaction(nil, "accounts.Transfer", &transfer{To: types.NewAddress(to.Public())})
+		tx.AppendAuthSignature(from.Public(), 0)
+		ts := tx.PrepareForSigning()
+		require.NoError(ts.AppendSign(signature.Context("test"), from), "AppendSign")
+		return ts.UnverifiedTransaction()
+	}
+
+	for round := uint64(1); round <= 2; round++ {
+		blk := block.NewGenesisBlock(testRuntimeID, 0)
+		blk.Header.Round = round
+		mt.blocks[round] = blk
+	}
+	malformed := types.UnverifiedTransaction{Body: []byte("malformed")}
+	aliceToBob := newTransfer(alice, bob)
+	charlieToAlice := newTransfer(charlie, alice)
+	bobToCharlie := newTransfer(bob, charlie)
+	mt.txs[1] = [][]byte{cbor.Marshal(aliceToBob)}
+	mt.txs[2] = [][]byte{cbor.Marshal(charlieToAlice), cbor.Marshal(bobToCharlie), cbor.Marshal(&malformed)}
+	mt.events[1] = []*coreClient.Event{{Key: []byte("transfer"), TxHash: aliceToBob.Hash()}}
+
+	txs, err := GetAccountTxs(ctx, rc, types.NewAddress(bob.Public()), 1, 2)
+	require.NoError(err, "GetAccountTxs")
+	require.Len(txs, 2)
+	require.EqualValues(1, txs[0].Round)
+	require.EqualValues(aliceToBob.Hash(), txs[0].TxHash)
+	require.Equal("accounts.Transfer", txs[0].Tx.Call.Method)
+	require.Len(txs[0].Events, 1, "events should be attached to the transaction")
+	require.EqualValues(2, txs[1].Round)
+	require.EqualValues(bobToCharlie.Hash(), txs[1].TxHash)
+	require.Empty(txs[1].Events)
+
+	txs, err = GetAccountTxs(ctx, rc, types.NewAddress(alice.Public()), 2, 2)
+	require.NoError(err, "GetAccountTxs")
+	require.Len(txs, 1)
+	require.EqualValues(charlieToAlice.Hash(), txs[0].TxHash)
+
+	_, err = GetAccountTxs(ctx, rc, types.NewAddress(alice.Public()), 0, MaxAccountTxsRounds)
+	require.Error(err, "GetAccountTxs should reject ranges that are too large")
+	_, err = GetAccountTxs(ctx, rc, types.NewAddress(alice.Public()), 2, 1)
+	require.Error(err, "GetAccountTxs should reject invalid ranges")
+}
+
+func TestGetEventsBySender(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	var (
+		alice = ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test alice"))
+		bob   = ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test bob"))
+	)
+	newTx := func(from signature.Signer) *types.UnverifiedTransaction {
+		tx := types.NewTransaction(nil, "accounts.Transfer", nil)
+		tx.AppendAuthSignature(from.Public(), 0)
+		ts := tx.PrepareForSigning()
+		require.NoError(ts.AppendSign(signature.Context("test"), from), "AppendSign")
+		return ts.UnverifiedTransaction()
+	}
+
+	blk := block.NewGenesisBlock(testRuntimeID, 0)
+	blk.Header.Round = 1
+	mt.blocks[1] = blk
+	aliceTx := newTx(alice)
+	bobTx := newTx(bob)
+	mt.txs[1] = [][]byte{cbor.Marshal(aliceTx), cbor.Marshal(bobTx)}
+	mt.events[1] = []*coreClient.Event{
+		{Key: []byte("alice transfer"), TxHash: aliceTx.Hash()},
+		{Key: []byte("bob transfer"), TxHash: bobTx.Hash()},
+		{Key: []byte("bob fee"), TxHash: bobTx.Hash()},
+	}
+
+	events, err := GetEventsBySender(ctx, rc, 1, types.NewAddress(bob.Public()))
+	require.NoError(err, "GetEventsBySender")
+	require.Len(events, 2)
+	require.EqualValues("bob transfer", events[0].Key)
+	require.EqualValues("bob fee", events[1].Key)
+
+	events, err = GetEventsBySender(ctx, rc, 1, types.NewAddress(alice.Public()))
+	require.NoError(err, "GetEventsBySender")
+	require.Len(events, 1)
+	require.EqualValues("alice transfer", events[0].Key)
+
+	charlie := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test charlie"))
+	events, err = GetEventsBySender(ctx, rc, 1, types.NewAddress(charlie.Public()))
+	require.NoError(err, "GetEventsBySender")
+	require.Empty(events)
+}
+
+func TestGetTransaction(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		mt.addBlock(1, [][]byte{cbor.Marshal(tx)}, [][]byte{cbor.Marshal(&types.CallResult{Ok: cbor.Marshal(nil)})})
+		return &types.CallResult{Ok: cbor.Marshal(nil)}, nil
+	}
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test signer"))
+	original := types.NewTransaction(nil, "test.Method", "hello")
+	original.AppendAuthSignature(signer.Public(), 7)
+	ts := original.PrepareForSigning()
+	require.NoError(ts.AppendSign(signature.Context("test"), signer), "AppendSign")
+	utx := ts.UnverifiedTransaction()
+	txHash := utx.Hash()
+	_, err := rc.SubmitTx(ctx, utx)
+	require.NoError(err, "SubmitTx")
+
+	tx, err := GetTransaction(ctx, rc, 1, txHash)
+	require.NoError(err, "GetTransaction")
+	require.EqualValues(original, tx)
+
+	var other hash.Hash
+	other.FromBytes([]byte("other"))
+	_, err = GetTransaction(ctx, rc, 1, other)
+	require.Error(err, "GetTransaction should fail for unknown transactions")
+	require.Contains(err.Error(), "not found")
+}
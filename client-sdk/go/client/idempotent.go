@@ -0,0 +1,73 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// FindTransaction looks for a transaction with the given hash in all blocks between fromRound and
+// toRound (both inclusive). It returns the round in which the transaction was included and true
+// if the transaction has been found.
+//
+// Note that this fetches all transactions in the given range so it should only be used for small
+// round ranges.
+func FindTransaction(ctx context.Context, rc RuntimeClient, txHash hash.Hash, fromRound, toRound uint64) (uint64, bool, error) {
+	for round := fromRound; round <= toRound; round++ {
+		txs, err := rc.GetTransactions(ctx, round)
+		if err != nil {
+			return 0, false, fmt.Errorf("failed to fetch transactions for round %d: %w", round, err)
+		}
+		for _, tx := range txs {
+			if h := tx.Hash(); h.Equal(&txHash) {
+				return round, true, nil
+			}
+		}
+	}
+	return 0, false, nil
+}
+
+// SubmitTxIdempotent submits the given transaction to the runtime transaction scheduler without
+// waiting for execution, unless the transaction has already been included in a block since
+// fromRound (inclusive).
+//
+// This makes it safe to resubmit a transaction after a submission timed out without knowing
+// whether the previous submission landed. It returns true if the transaction has been submitted
+// and false if submission was skipped because the transaction was already included.
+//
+// In case the resubmission is rejected, inclusion is checked again as the previous submission may
+// have been included in the meantime (the resubmission then fails due to an invalid nonce). Only
+// included transactions can be detected, so when the previous submission is still pending in the
+// node's transaction pool the resubmission is expected to fail and its error is returned.
+func SubmitTxIdempotent(ctx context.Context, rc RuntimeClient, tx *types.UnverifiedTransaction, fromRound uint64) (bool, error) {
+	included, err := isIncludedSince(ctx, rc, tx, fromRound)
+	if err != nil {
+		return false, err
+	}
+	if included {
+		return false, nil
+	}
+
+	if err = rc.SubmitTxNoWait(ctx, tx); err != nil {
+		if included, _ = isIncludedSince(ctx, rc, tx, fromRound); included {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// isIncludedSince checks whether the given transaction has been included in a block since
+// fromRound (inclusive).
+func isIncludedSince(ctx context.Context, rc RuntimeClient, tx *types.UnverifiedTransaction, fromRound uint64) (bool, error) {
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+
+	_, found, err := FindTransaction(ctx, rc, tx.Hash(), fromRound, blk.Header.Round)
+	return found, err
+}
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestSubmitTxIdempotent(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	included := types.UnverifiedTransaction{Body: []byte("included")}
+	for round := uint64(0); round <= 2; round++ {
+		blk := block.NewGenesisBlock(testRuntimeID, 0)
+		blk.Header.Round = round
+		mt.blocks[round] = blk
+	}
+	mt.txs[2] = [][]byte{cbor.Marshal(&included)}
+
+	round, found, err := FindTransaction(ctx, rc, included.Hash(), 0, 2)
+	require.NoError(err, "FindTransaction")
+	require.True(found, "transaction should be found")
+	require.EqualValues(2, round)
+
+	// Resubmitting an already included transaction should be skipped.
+	submitted, err := SubmitTxIdempotent(ctx, rc, &included, 1)
+	require.NoError(err, "SubmitTxIdempotent")
+	require.False(submitted, "already included transaction should not be resubmitted")
+	require.Empty(mt.submitted)
+
+	// A transaction that has not been included should be submitted.
+	pending := types.UnverifiedTransaction{Body: []byte("pending")}
+	submitted, err = SubmitTxIdempotent(ctx, rc, &pending, 1)
+	require.NoError(err, "SubmitTxIdempotent")
+	require.True(submitted, "transaction should be submitted")
+	require.Len(mt.submitted, 1)
+	require.EqualValues(pending.Hash(), mt.submitted[0].Hash())
+
+	// A previous submission included while resubmitting should not be reported as a failure.
+	raced := types.UnverifiedTransaction{Body: []byte("raced")}
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		mt.addBlock(3, [][]byte{cbor.Marshal(tx)}, nil)
+		return nil, fmt.Errorf("invalid nonce")
+	}
+	submitted, err = SubmitTxIdempotent(ctx, rc, &raced, 1)
+	require.NoError(err, "SubmitTxIdempotent")
+	require.False(submitted, "transaction included in the meantime should not be reported as submitted")
+
+	// Other rejections should be reported.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return nil, fmt.Errorf("invalid nonce")
+	}
+	_, err = SubmitTxIdempotent(ctx, rc, &pending, 1)
+	require.Error(err, "SubmitTxIdempotent should fail when the transaction is still pending")
+}
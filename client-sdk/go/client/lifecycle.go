@@ -0,0 +1,158 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// TxState is a state in the lifecycle of a submitted transaction.
+type TxState uint8
+
+// Transaction lifecycle states.
+const (
+	// TxSubmitted means that the transaction is being submitted to the node.
+	TxSubmitted TxState = iota
+	// TxPending means that the node accepted the transaction into its transaction pool.
+	TxPending
+	// TxIncluded means that the transaction has been included in a block.
+	TxIncluded
+	// TxSucceeded means that the transaction has been executed successfully. This is a terminal
+	// state.
+	TxSucceeded
+	// TxFailed means that the transaction has been executed but the call failed or its result
+	// could not be retrieved. This is a terminal state.
+	TxFailed
+	// TxDropped means that the transaction has been rejected or that it has not been included
+	// before the drop timeout expired or the context was done. This is a terminal state.
+	TxDropped
+)
+
+// String returns a string representation of the transaction state.
+func (s TxState) String() string {
+	switch s {
+	case TxSubmitted:
+		return "submitted"
+	case TxPending:
+		return "pending"
+	case TxIncluded:
+		return "included"
+	case TxSucceeded:
+		return "succeeded"
+	case TxFailed:
+		return "failed"
+	case TxDropped:
+		return "dropped"
+	default:
+		return fmt.Sprintf("[unknown state: %d]", uint8(s))
+	}
+}
+
+// IsTerminal returns true if no further state transitions follow the state.
+func (s TxState) IsTerminal() bool {
+	return s >= TxSucceeded
+}
+
+// TxStatus is a transaction lifecycle state transition.
+type TxStatus struct {
+	// State is the new state of the transaction.
+	State TxState
+	// Round is the round in which the transaction has been included (when known).
+	Round uint64
+	// Result is the call result of the executed transaction (in the TxSucceeded and TxFailed
+	// states).
+	Result *types.CallResult
+	// Err is the reason the transaction has been dropped (in the TxDropped state) or the reason
+	// the result could not be retrieved (in the TxFailed state).
+	Err error
+}
+
+// maxTxStatusUpdates is the maximum number of transitions reported by SubmitWithLifecycle
+// (submitted, pending, included and a terminal state).
+const maxTxStatusUpdates = 4
+
+type lifecycleOptions struct {
+	dropTimeout time.Duration
+}
+
+// LifecycleOption is an option for configuring SubmitWithLifecycle.
+type LifecycleOption func(o *lifecycleOptions)
+
+// WithDropTimeout bounds the time spent waiting for the transaction to be included. In case the
+// transaction is not included in time, it is reported as dropped with an error wrapping
+// context.DeadlineExceeded.
+func WithDropTimeout(timeout time.Duration) LifecycleOption {
+	return func(o *lifecycleOptions) {
+		o.dropTimeout = timeout
+	}
+}
+
+// SubmitWithLifecycle submits the given transaction and returns a channel which receives each
+// transition in the transaction's lifecycle: submitted, pending (accepted by the node), included
+// and finally succeeded or failed. In case the node rejects the transaction, or the drop timeout
+// expires or the context is done before the transaction is included, the transaction is reported
+// as dropped.
+//
+// The transitions are produced by a goroutine which runs until a terminal state is reached. As a
+// transaction may never be included, without WithDropTimeout the goroutine keeps waiting until
+// the context is done, so either set a drop timeout or cancel the context when no longer
+// interested in the transaction. The channel is closed after a terminal state has been sent. It
+// is buffered to hold all of the transitions so the caller may stop reading from it at any time
+// without blocking the goroutine.
+func SubmitWithLifecycle(ctx context.Context, rc RuntimeClient, tx *types.UnverifiedTransaction, opts ...LifecycleOption) (<-chan TxStatus, error) {
+	var o lifecycleOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	fromRound := blk.Header.Round + 1
+
+	ch := make(chan TxStatus, maxTxStatusUpdates)
+	go func() {
+		defer close(ch)
+
+		ch <- TxStatus{State: TxSubmitted}
+		if err := rc.SubmitTxNoWait(ctx, tx); err != nil {
+			ch <- TxStatus{State: TxDropped, Err: err}
+			return
+		}
+		ch <- TxStatus{State: TxPending}
+
+		awaitCtx := ctx
+		if o.dropTimeout > 0 {
+			var cancel context.CancelFunc
+			awaitCtx, cancel = context.WithTimeout(ctx, o.dropTimeout)
+			defer cancel()
+		}
+		round, idx, err := awaitInclusion(awaitCtx, rc, tx.Hash(), fromRound)
+		switch {
+		case err == nil:
+		case awaitCtx.Err() != nil && ctx.Err() == nil:
+			ch <- TxStatus{State: TxDropped, Err: fmt.Errorf("transaction not included within %s: %w", o.dropTimeout, awaitCtx.Err())}
+			return
+		default:
+			ch <- TxStatus{State: TxDropped, Err: err}
+			return
+		}
+		ch <- TxStatus{State: TxIncluded, Round: round}
+
+		result, err := GetTransactionResult(ctx, rc, round, idx)
+		if err != nil {
+			// The transaction has been executed, but its result is not available.
+			ch <- TxStatus{State: TxFailed, Round: round, Err: fmt.Errorf("failed to fetch transaction result: %w", err)}
+			return
+		}
+		state := TxSucceeded
+		if !result.IsSuccess() {
+			state = TxFailed
+		}
+		ch <- TxStatus{State: state, Round: round, Result: result}
+	}()
+	return ch, nil
+}
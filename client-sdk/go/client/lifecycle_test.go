@@ -0,0 +1,175 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func collectStatuses(ch <-chan TxStatus) []TxStatus {
+	var statuses []TxStatus
+	for st := range ch {
+		statuses = append(statuses, st)
+	}
+	return statuses
+}
+
+func TestSubmitWithLifecycle(t *testing.T) {
+	require := require.New(t)
+
+	awaitPollInterval = 10 * time.Millisecond
+	defer func() {
+		awaitPollInterval = time.Second
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+	mt.addBlock(1, nil, nil)
+
+	// The transaction is included in the next block.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		result := &types.CallResult{Ok: cbor.Marshal("ok")}
+		mt.addBlock(2, [][]byte{cbor.Marshal(tx)}, [][]byte{cbor.Marshal(result)})
+		return result, nil
+	}
+	tx := types.UnverifiedTransaction{Body: []byte("hello world")}
+	ch, err := SubmitWithLifecycle(ctx, rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	statuses := collectStatuses(ch)
+	require.Len(statuses, 4)
+	require.Equal(TxSubmitted, statuses[0].State)
+	require.Equal(TxPending, statuses[1].State)
+	require.Equal(TxIncluded, statuses[2].State)
+	require.EqualValues(2, statuses[2].Round)
+	require.Equal(TxSucceeded, statuses[3].State)
+	require.EqualValues(2, statuses[3].Round)
+	require.EqualValues(cbor.Marshal("ok"), statuses[3].Result.Ok)
+	require.True(statuses[3].State.IsTerminal())
+
+	// Failed calls.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		result := &types.CallResult{Failed: &types.FailedCallResult{Module: "test", Code: 1}}
+		mt.addBlock(3, [][]byte{cbor.Marshal(tx)}, [][]byte{cbor.Marshal(result)})
+		return result, nil
+	}
+	ch, err = SubmitWithLifecycle(ctx, rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	statuses = collectStatuses(ch)
+	require.Len(statuses, 4)
+	require.Equal(TxFailed, statuses[3].State)
+	require.EqualValues("test", statuses[3].Result.Failed.Module)
+
+	// Rejected transactions are dropped.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		return nil, fmt.Errorf("invalid transaction")
+	}
+	ch, err = SubmitWithLifecycle(ctx, rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	statuses = collectStatuses(ch)
+	require.Len(statuses, 2)
+	require.Equal(TxSubmitted, statuses[0].State)
+	require.Equal(TxDropped, statuses[1].State)
+	require.Error(statuses[1].Err)
+
+	// Transactions that are never included are dropped when the context is done.
+	mt.submitHandler = nil
+	tctx, tcancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer tcancel()
+	ch, err = SubmitWithLifecycle(tctx, rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	statuses = collectStatuses(ch)
+	require.Len(statuses, 3)
+	require.Equal(TxDropped, statuses[2].State)
+	require.ErrorIs(statuses[2].Err, context.DeadlineExceeded)
+}
+
+func TestSubmitWithLifecycleDropTimeout(t *testing.T) {
+	require := require.New(t)
+
+	awaitPollInterval = 10 * time.Millisecond
+	defer func() {
+		awaitPollInterval = time.Second
+	}()
+
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+	mt.addBlock(1, nil, nil)
+	tx := types.UnverifiedTransaction{Body: []byte("hello world")}
+
+	// Transactions that are never included should be given up on after the drop timeout, even if
+	// the context is never done.
+	ch, err := SubmitWithLifecycle(context.Background(), rc, &tx, WithDropTimeout(50*time.Millisecond))
+	require.NoError(err, "SubmitWithLifecycle")
+	done := make(chan []TxStatus, 1)
+	go func() {
+		done <- collectStatuses(ch)
+	}()
+	var statuses []TxStatus
+	select {
+	case statuses = <-done:
+	case <-time.After(time.Second):
+		require.FailNow("the channel should be closed after the drop timeout")
+	}
+	require.Len(statuses, 3)
+	require.Equal(TxDropped, statuses[2].State)
+	require.ErrorIs(statuses[2].Err, context.DeadlineExceeded)
+	require.Contains(statuses[2].Err.Error(), "not included within")
+
+	// Transactions included before the drop timeout should not be affected by it.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		result := &types.CallResult{Ok: cbor.Marshal("ok")}
+		mt.addBlock(2, [][]byte{cbor.Marshal(tx)}, [][]byte{cbor.Marshal(result)})
+		return result, nil
+	}
+	ch, err = SubmitWithLifecycle(context.Background(), rc, &tx, WithDropTimeout(time.Second))
+	require.NoError(err, "SubmitWithLifecycle")
+	statuses = collectStatuses(ch)
+	require.Len(statuses, 4)
+	require.Equal(TxSucceeded, statuses[3].State)
+}
+
+func TestSubmitWithLifecycleNoReader(t *testing.T) {
+	require := require.New(t)
+
+	awaitPollInterval = 10 * time.Millisecond
+	defer func() {
+		awaitPollInterval = time.Second
+	}()
+
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+	mt.addBlock(1, nil, nil)
+	tx := types.UnverifiedTransaction{Body: []byte("hello world")}
+
+	// Cancelling the context should not block on a channel that nobody reads from.
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, err := SubmitWithLifecycle(ctx, rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	cancel()
+	require.Eventually(func() bool { return len(ch) == 3 }, time.Second, 10*time.Millisecond, "all transitions should be sent without a reader")
+	statuses := collectStatuses(ch)
+	require.Len(statuses, 3)
+	require.Equal(TxDropped, statuses[2].State)
+	require.ErrorIs(statuses[2].Err, context.Canceled)
+
+	// Transactions that are executed should not block either, even if the context is never done.
+	mt.submitHandler = func(tx *types.UnverifiedTransaction) (*types.CallResult, error) {
+		result := &types.CallResult{Ok: cbor.Marshal("ok")}
+		mt.addBlock(2, [][]byte{cbor.Marshal(tx)}, [][]byte{cbor.Marshal(result)})
+		return result, nil
+	}
+	ch, err = SubmitWithLifecycle(context.Background(), rc, &tx)
+	require.NoError(err, "SubmitWithLifecycle")
+	require.Eventually(func() bool { return len(ch) == 4 }, time.Second, 10*time.Millisecond, "all transitions should be sent without a reader")
+	statuses = collectStatuses(ch)
+	require.Equal(TxSucceeded, statuses[3].State)
+}
@@ -0,0 +1,38 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// IsConfidential checks whether the runtime of the given runtime client is confidential, i.e.
+// whether it has a key manager configured in its registry descriptor at the latest height.
+// Transactions for confidential runtimes should be encrypted before submission.
+//
+// This requires a runtime client created by New or NewWithTransport whose transport implements
+// RegistryTransport.
+func IsConfidential(ctx context.Context, rc RuntimeClient) (bool, error) {
+	impl, ok := rc.(*runtimeClient)
+	if !ok {
+		return false, errRegistryNotSupported
+	}
+	return impl.isConfidential(ctx)
+}
+
+func (rc *runtimeClient) isConfidential(ctx context.Context) (bool, error) {
+	reg, ok := rc.t.(RegistryTransport)
+	if !ok {
+		return false, errRegistryNotSupported
+	}
+	rt, err := reg.GetRuntime(ctx, &registry.NamespaceQuery{
+		Height: consensus.HeightLatest,
+		ID:     rc.runtimeID,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch runtime descriptor: %w", err)
+	}
+	return rt.KeyManager != nil, nil
+}
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+)
+
+// registryTransport is an in-memory transport that also supports fetching a single runtime
+// descriptor from the registry.
+type registryTransport struct {
+	*memoryTransport
+
+	descriptor *registry.Runtime
+}
+
+// Implements RegistryTransport.
+func (t *registryTransport) GetRuntime(ctx context.Context, query *registry.NamespaceQuery) (*registry.Runtime, error) {
+	if t.descriptor == nil || !t.descriptor.ID.Equal(&query.ID) {
+		return nil, registry.ErrNoSuchRuntime
+	}
+	return t.descriptor, nil
+}
+
+func TestIsConfidential(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+
+	_, err := IsConfidential(ctx, NewWithTransport(newMemoryTransport(), testRuntimeID))
+	require.ErrorIs(err, errRegistryNotSupported, "IsConfidential should fail without registry access")
+
+	rt := &registryTransport{memoryTransport: newMemoryTransport()}
+	for _, rc := range []RuntimeClient{
+		NewWithTransport(rt, testRuntimeID),
+		NewWithTransport(rt, testRuntimeID, WithConcurrency(1)),
+	} {
+		rt.descriptor = nil
+		_, err = IsConfidential(ctx, rc)
+		require.Error(err, "IsConfidential should fail for unknown runtimes")
+
+		rt.descriptor = &registry.Runtime{
+			ID:   testRuntimeID,
+			Kind: registry.KindCompute,
+		}
+		confidential, err := IsConfidential(ctx, rc)
+		require.NoError(err, "IsConfidential")
+		require.False(confidential, "runtime without a key manager should not be confidential")
+
+		keyManagerID := common.NewTestNamespaceFromSeed([]byte("oasis-sdk/client: test key manager"), 0)
+		rt.descriptor.KeyManager = &keyManagerID
+		confidential, err = IsConfidential(ctx, rc)
+		require.NoError(err, "IsConfidential")
+		require.True(confidential, "runtime with a key manager should be confidential")
+	}
+}
@@ -0,0 +1,53 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
+)
+
+// ErrStateRootMismatch is the error returned by CompareStateRoots when the two runtime clients
+// report different state roots for the same round.
+type ErrStateRootMismatch struct {
+	// Round is the compared round.
+	Round uint64
+	// A is the state root reported by the first client.
+	A hash.Hash
+	// B is the state root reported by the second client.
+	B hash.Hash
+}
+
+// Error implements error.
+func (e *ErrStateRootMismatch) Error() string {
+	return fmt.Sprintf("state root mismatch in round %d (a: %s b: %s)", e.Round, e.A, e.B)
+}
+
+// StateRootAt returns the runtime state root after the given round.
+func StateRootAt(ctx context.Context, rc RuntimeClient, round uint64) (hash.Hash, error) {
+	blk, err := rc.GetBlock(ctx, round)
+	if err != nil {
+		return hash.Hash{}, fmt.Errorf("failed to fetch block for round %d: %w", round, err)
+	}
+	return blk.Header.StateRoot, nil
+}
+
+// CompareStateRoots checks that both runtime clients (usually connected to different nodes)
+// report the same state root for the given round. In case the state roots differ, an
+// ErrStateRootMismatch is returned.
+//
+// Note that RoundLatest should not be used as the nodes may be at different rounds.
+func CompareStateRoots(ctx context.Context, a, b RuntimeClient, round uint64) error {
+	rootA, err := StateRootAt(ctx, a, round)
+	if err != nil {
+		return err
+	}
+	rootB, err := StateRootAt(ctx, b, round)
+	if err != nil {
+		return err
+	}
+	if !rootA.Equal(&rootB) {
+		return &ErrStateRootMismatch{Round: round, A: rootA, B: rootB}
+	}
+	return nil
+}
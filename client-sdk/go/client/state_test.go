@@ -0,0 +1,43 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareStateRoots(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mtA := newMemoryTransport()
+	mtB := newMemoryTransport()
+	rcA := NewWithTransport(mtA, testRuntimeID)
+	rcB := NewWithTransport(mtB, testRuntimeID)
+
+	blkA := mtA.addBlock(1, nil, nil)
+	blkA.Header.StateRoot.FromBytes([]byte("state"))
+	blkB := mtB.addBlock(1, nil, nil)
+	blkB.Header.StateRoot.FromBytes([]byte("state"))
+
+	root, err := StateRootAt(ctx, rcA, 1)
+	require.NoError(err, "StateRootAt")
+	require.EqualValues(blkA.Header.StateRoot, root)
+
+	err = CompareStateRoots(ctx, rcA, rcB, 1)
+	require.NoError(err, "CompareStateRoots should succeed for matching roots")
+
+	blkB.Header.StateRoot.FromBytes([]byte("diverged"))
+	err = CompareStateRoots(ctx, rcA, rcB, 1)
+	require.Error(err, "CompareStateRoots should fail for different roots")
+	var mismatch *ErrStateRootMismatch
+	require.True(errors.As(err, &mismatch))
+	require.EqualValues(1, mismatch.Round)
+	require.EqualValues(blkA.Header.StateRoot, mismatch.A)
+	require.EqualValues(blkB.Header.StateRoot, mismatch.B)
+
+	_, err = StateRootAt(ctx, rcA, 2)
+	require.Error(err, "StateRootAt should fail for missing rounds")
+}
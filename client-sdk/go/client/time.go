@@ -0,0 +1,56 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+// BlockTime returns the timestamp of the given runtime block.
+func BlockTime(blk *block.Block) time.Time {
+	return time.Unix(int64(blk.Header.Timestamp), 0)
+}
+
+// LatestBlockTime returns the timestamp of the latest runtime block.
+func LatestBlockTime(ctx context.Context, rc RuntimeClient) (time.Time, error) {
+	blk, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	return BlockTime(blk), nil
+}
+
+// inclusionTimeSamples is the number of recent block intervals sampled by EstimateInclusionTime.
+const inclusionTimeSamples = 10
+
+// EstimateInclusionTime estimates how long it will take for a newly submitted transaction to be
+// included in a block, based on the average interval between the most recent runtime blocks.
+//
+// This is a best-effort estimate which does not take transaction scheduler load into account.
+func EstimateInclusionTime(ctx context.Context, rc RuntimeClient) (time.Duration, error) {
+	latest, err := rc.GetBlock(ctx, RoundLatest)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch latest block: %w", err)
+	}
+	if latest.Header.Round == 0 {
+		return 0, fmt.Errorf("not enough blocks to estimate inclusion time")
+	}
+
+	samples := uint64(inclusionTimeSamples)
+	if latest.Header.Round < samples {
+		samples = latest.Header.Round
+	}
+	oldestRound := latest.Header.Round - samples
+	oldest, err := rc.GetBlock(ctx, oldestRound)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch block for round %d: %w", oldestRound, err)
+	}
+
+	elapsed := BlockTime(latest).Sub(BlockTime(oldest))
+	if elapsed < 0 {
+		return 0, fmt.Errorf("block timestamps are not monotonic")
+	}
+	return elapsed / time.Duration(samples), nil
+}
@@ -0,0 +1,64 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+)
+
+func TestLatestBlockTime(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	now := time.Now()
+	blk := block.NewGenesisBlock(testRuntimeID, 0)
+	blk.Header.Round = 1
+	blk.Header.Timestamp = uint64(now.Unix())
+	mt.blocks[1] = blk
+
+	require.EqualValues(now.Unix(), BlockTime(blk).Unix())
+
+	blkTime, err := LatestBlockTime(ctx, rc)
+	require.NoError(err, "LatestBlockTime")
+	require.WithinDuration(now, blkTime, time.Second)
+}
+
+func TestEstimateInclusionTime(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	genesis := block.NewGenesisBlock(testRuntimeID, 1000)
+	mt.blocks[0] = genesis
+	_, err := EstimateInclusionTime(ctx, rc)
+	require.Error(err, "EstimateInclusionTime should fail with only the genesis block")
+
+	// A few blocks 6 seconds apart.
+	for round := uint64(1); round <= 3; round++ {
+		blk := block.NewGenesisBlock(testRuntimeID, 1000+6*round)
+		blk.Header.Round = round
+		mt.blocks[round] = blk
+	}
+	interval, err := EstimateInclusionTime(ctx, rc)
+	require.NoError(err, "EstimateInclusionTime")
+	require.EqualValues(6*time.Second, interval)
+
+	// Only the most recent blocks should be sampled.
+	for round := uint64(4); round <= 3+inclusionTimeSamples; round++ {
+		blk := block.NewGenesisBlock(testRuntimeID, 1018+2*(round-3))
+		blk.Header.Round = round
+		mt.blocks[round] = blk
+	}
+	interval, err = EstimateInclusionTime(ctx, rc)
+	require.NoError(err, "EstimateInclusionTime")
+	require.EqualValues(2*time.Second, interval)
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"math"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 
@@ -10,6 +11,18 @@ import (
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
+const (
+	// GasAuto is a special gas amount which causes the transaction builder to estimate the gas
+	// needed by the transaction when it is signed (see SetFeeGas).
+	GasAuto = math.MaxUint64
+
+	// gasAutoPaddingPercent is the percentage by which the estimated gas is increased to account
+	// for state changes between estimation and execution.
+	gasAutoPaddingPercent = 10
+
+	methodEstimateGas = "core.EstimateGas"
+)
+
 // TransactionBuilder is a helper for building and submitting transactions.
 type TransactionBuilder struct {
 	rc RuntimeClient
@@ -32,6 +45,9 @@ func (tb *TransactionBuilder) SetFeeAmount(amount types.BaseUnits) *TransactionB
 }
 
 // SetFeeGas configures the maximum gas amount that can be used by the transaction.
+//
+// When set to GasAuto, the gas amount is estimated (and padded) when the transaction is first
+// signed. The signer information must be configured before that.
 func (tb *TransactionBuilder) SetFeeGas(gas uint64) *TransactionBuilder {
 	tb.tx.AuthInfo.Fee.Gas = gas
 	return tb
@@ -56,11 +72,40 @@ func (tb *TransactionBuilder) GetTransaction() *types.Transaction {
 	return tb.tx
 }
 
+// estimateGas replaces the GasAuto gas amount with the estimated gas amount.
+func (tb *TransactionBuilder) estimateGas(ctx context.Context) error {
+	if tb.tx.AuthInfo.Fee.Gas != GasAuto {
+		return nil
+	}
+
+	// The transaction is simulated with its gas set to GasAuto so that it does not run out.
+	var gas uint64
+	if err := tb.rc.Query(ctx, RoundLatest, methodEstimateGas, tb.tx, &gas); err != nil {
+		return fmt.Errorf("failed to estimate gas: %w", err)
+	}
+	tb.tx.AuthInfo.Fee.Gas = padGas(gas)
+	return nil
+}
+
+// padGas increases the given estimated gas amount by gasAutoPaddingPercent, saturating at the
+// maximum gas amount.
+func padGas(gas uint64) uint64 {
+	padding := gas / 100 * gasAutoPaddingPercent
+	padding += gas % 100 * gasAutoPaddingPercent / 100
+	if gas > math.MaxUint64-padding {
+		return math.MaxUint64
+	}
+	return gas + padding
+}
+
 // AppendSign signs the transaction and appends the signature.
 //
 // The signer must be specified in the AuthInfo.
 func (tb *TransactionBuilder) AppendSign(ctx context.Context, signer signature.Signer) error {
 	if tb.ts == nil {
+		if err := tb.estimateGas(ctx); err != nil {
+			return err
+		}
 		tb.ts = tb.tx.PrepareForSigning()
 	}
 	rtInfo, err := tb.rc.GetInfo(ctx)
@@ -90,6 +135,15 @@ func (tb *TransactionBuilder) SubmitTx(ctx context.Context, rsp interface{}) err
 	return nil
 }
 
+// Execute submits a transaction to the runtime transaction scheduler, waits for transaction
+// execution and gathers its execution results (see Execute).
+func (tb *TransactionBuilder) Execute(ctx context.Context, opts ...ExecuteOption) (*ExecutionResult, error) {
+	if tb.ts == nil {
+		return nil, fmt.Errorf("unable to submit unsigned transaction")
+	}
+	return Execute(ctx, tb.rc, tb.ts.UnverifiedTransaction(), opts...)
+}
+
 // SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does not wait for
 // transaction execution.
 func (tb *TransactionBuilder) SubmitTxNoWait(ctx context.Context) error {
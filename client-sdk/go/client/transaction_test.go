@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestTransactionBuilderGasAuto(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	mt := newMemoryTransport()
+	rc := NewWithTransport(mt, testRuntimeID)
+
+	var estimated int
+	mt.queryHandler = func(round uint64, method string, args cbor.RawMessage) (interface{}, error) {
+		require.EqualValues("core.EstimateGas", method)
+		var tx types.Transaction
+		require.NoError(cbor.Unmarshal(args, &tx), "estimation should be given the transaction")
+		require.EqualValues("evm.Create", tx.Call.Method)
+		require.EqualValues(uint64(math.MaxUint64), tx.AuthInfo.Fee.Gas, "estimation should use a high gas limit")
+		estimated++
+		return uint64(1000), nil
+	}
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/client: test signer"))
+	tb := NewTransactionBuilder(rc, "evm.Create", []byte("init code")).
+		SetFeeGas(GasAuto).
+		AppendAuthSignature(signer.Public(), 0)
+	err := tb.AppendSign(ctx, signer)
+	require.NoError(err, "AppendSign")
+	require.EqualValues(1, estimated)
+	require.EqualValues(1100, tb.GetTransaction().AuthInfo.Fee.Gas, "estimated gas should be padded")
+
+	err = tb.SubmitTx(ctx, nil)
+	require.NoError(err, "SubmitTx")
+	require.Len(mt.submitted, 1)
+	var tx types.Transaction
+	require.NoError(cbor.Unmarshal(mt.submitted[0].Body, &tx))
+	require.EqualValues(1100, tx.AuthInfo.Fee.Gas)
+
+	// Explicit gas amounts should not be estimated.
+	tb = NewTransactionBuilder(rc, "evm.Create", []byte("init code")).
+		SetFeeGas(5000).
+		AppendAuthSignature(signer.Public(), 1)
+	err = tb.AppendSign(ctx, signer)
+	require.NoError(err, "AppendSign")
+	require.EqualValues(1, estimated)
+	require.EqualValues(5000, tb.GetTransaction().AuthInfo.Fee.Gas)
+}
+
+func TestPadGas(t *testing.T) {
+	require := require.New(t)
+
+	require.EqualValues(0, padGas(0))
+	require.EqualValues(1100, padGas(1000))
+	require.EqualValues(1054, padGas(959))
+	require.EqualValues(uint64(math.MaxUint64), padGas(math.MaxUint64/100*95), "padding should saturate")
+	require.EqualValues(uint64(math.MaxUint64), padGas(math.MaxUint64))
+}
@@ -0,0 +1,92 @@
+package client
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	consensus "github.com/oasisprotocol/oasis-core/go/consensus/api"
+	registry "github.com/oasisprotocol/oasis-core/go/registry/api"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+)
+
+// Transport is the transport used by the runtime client to communicate with an Oasis node.
+//
+// The default implementation (see NewGRPCTransport) talks to the node over gRPC, but other
+// transports (e.g. an in-memory one for tests) can be used via NewWithTransport.
+type Transport interface {
+	// GetChainContext returns the consensus layer chain domain separation context.
+	GetChainContext(ctx context.Context) (string, error)
+
+	// SubmitTx submits a transaction to the runtime transaction scheduler and waits
+	// for transaction execution results.
+	SubmitTx(ctx context.Context, request *coreClient.SubmitTxRequest) ([]byte, error)
+
+	// SubmitTxNoWait submits a transaction to the runtime transaction scheduler but does
+	// not wait for transaction execution.
+	SubmitTxNoWait(ctx context.Context, request *coreClient.SubmitTxRequest) error
+
+	// GetGenesisBlock returns the genesis block.
+	GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error)
+
+	// GetBlock fetches the given runtime block.
+	GetBlock(ctx context.Context, request *coreClient.GetBlockRequest) (*block.Block, error)
+
+	// GetTxs fetches all runtime transactions in a given block.
+	GetTxs(ctx context.Context, request *coreClient.GetTxsRequest) ([][]byte, error)
+
+	// GetEvents returns all events emitted in a given block.
+	GetEvents(ctx context.Context, request *coreClient.GetEventsRequest) ([]*coreClient.Event, error)
+
+	// Query makes a runtime-specific query.
+	Query(ctx context.Context, request *coreClient.QueryRequest) (*coreClient.QueryResponse, error)
+
+	// WatchBlocks subscribes to blocks for a specific runtimes.
+	WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error)
+}
+
+// TxResultTransport is an optional interface implemented by transports that can fetch individual
+// transactions together with their results (e.g. the gRPC transport). It is required by
+// GetTransactionResult and the helpers built on top of it.
+type TxResultTransport interface {
+	// GetTx fetches the given runtime transaction together with its output.
+	GetTx(ctx context.Context, request *coreClient.GetTxRequest) (*coreClient.TxResult, error)
+}
+
+// RegistryTransport is an optional interface implemented by transports that can query the
+// consensus layer registry (e.g. the gRPC transport). It is required by IsConfidential.
+type RegistryTransport interface {
+	// GetRuntime fetches the given runtime descriptor.
+	GetRuntime(ctx context.Context, query *registry.NamespaceQuery) (*registry.Runtime, error)
+}
+
+type grpcTransport struct {
+	coreClient.RuntimeClient
+
+	cs  consensus.ClientBackend
+	reg registry.Backend
+}
+
+// Implements Transport.
+func (t *grpcTransport) GetChainContext(ctx context.Context) (string, error) {
+	return t.cs.GetChainContext(ctx)
+}
+
+// Implements RegistryTransport.
+func (t *grpcTransport) GetRuntime(ctx context.Context, query *registry.NamespaceQuery) (*registry.Runtime, error) {
+	return t.reg.GetRuntime(ctx, query)
+}
+
+// NewGRPCTransport creates a new transport that talks to an Oasis node over the given gRPC
+// connection.
+func NewGRPCTransport(conn *grpc.ClientConn) Transport {
+	return &grpcTransport{
+		RuntimeClient: coreClient.NewRuntimeClient(conn),
+		cs:            consensus.NewConsensusClient(conn),
+		reg:           registry.NewRegistryClient(conn),
+	}
+}
@@ -0,0 +1,169 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+	"github.com/oasisprotocol/oasis-core/go/roothash/api/block"
+	coreClient "github.com/oasisprotocol/oasis-core/go/runtime/client/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var testRuntimeID = common.NewTestNamespaceFromSeed([]byte("oasis-sdk/client: test runtime"), 0)
+
+// memoryTransport is an in-memory transport used for testing.
+type memoryTransport struct {
+	sync.Mutex
+
+	chainContext      string
+	chainContextCalls int
+
+	blocks  map[uint64]*block.Block
+	txs     map[uint64][][]byte
+	results map[uint64][][]byte
+	events  map[uint64][]*coreClient.Event
+
+	// blockCh if set is returned by WatchBlocks, otherwise watching blocks is not supported.
+	blockCh chan *roothash.AnnotatedBlock
+
+	// submitHandler is called for each submitted transaction.
+	submitHandler func(tx *types.UnverifiedTransaction) (*types.CallResult, error)
+	// submitted are all transactions submitted via this transport.
+	submitted []*types.UnverifiedTransaction
+
+	// queryHandler is called for each query.
+	queryHandler func(round uint64, method string, args cbor.RawMessage) (interface{}, error)
+}
+
+func (mt *memoryTransport) GetChainContext(ctx context.Context) (string, error) {
+	mt.chainContextCalls++
+	return mt.chainContext, nil
+}
+
+func (mt *memoryTransport) SubmitTx(ctx context.Context, request *coreClient.SubmitTxRequest) ([]byte, error) {
+	var tx types.UnverifiedTransaction
+	if err := cbor.Unmarshal(request.Data, &tx); err != nil {
+		return nil, err
+	}
+	mt.submitted = append(mt.submitted, &tx)
+
+	if mt.submitHandler == nil {
+		return cbor.Marshal(&types.CallResult{Ok: cbor.Marshal(nil)}), nil
+	}
+	result, err := mt.submitHandler(&tx)
+	if err != nil {
+		return nil, err
+	}
+	return cbor.Marshal(result), nil
+}
+
+func (mt *memoryTransport) SubmitTxNoWait(ctx context.Context, request *coreClient.SubmitTxRequest) error {
+	_, err := mt.SubmitTx(ctx, request)
+	return err
+}
+
+func (mt *memoryTransport) GetGenesisBlock(ctx context.Context, runtimeID common.Namespace) (*block.Block, error) {
+	return mt.GetBlock(ctx, &coreClient.GetBlockRequest{RuntimeID: runtimeID, Round: 0})
+}
+
+func (mt *memoryTransport) latestRound() uint64 {
+	var latest uint64
+	for round := range mt.blocks {
+		if round > latest {
+			latest = round
+		}
+	}
+	return latest
+}
+
+// addBlock adds a new block with the given transactions and their serialized call results.
+func (mt *memoryTransport) addBlock(round uint64, txs, results [][]byte) *block.Block {
+	mt.Lock()
+	defer mt.Unlock()
+
+	blk := block.NewGenesisBlock(testRuntimeID, 0)
+	blk.Header.Round = round
+	mt.blocks[round] = blk
+	mt.txs[round] = txs
+	mt.results[round] = results
+	return blk
+}
+
+func (mt *memoryTransport) GetBlock(ctx context.Context, request *coreClient.GetBlockRequest) (*block.Block, error) {
+	mt.Lock()
+	defer mt.Unlock()
+
+	round := request.Round
+	if round == RoundLatest {
+		round = mt.latestRound()
+	}
+	blk, ok := mt.blocks[round]
+	if !ok {
+		return nil, fmt.Errorf("block not found")
+	}
+	return blk, nil
+}
+
+func (mt *memoryTransport) GetTxs(ctx context.Context, request *coreClient.GetTxsRequest) ([][]byte, error) {
+	mt.Lock()
+	defer mt.Unlock()
+	return mt.txs[request.Round], nil
+}
+
+func (mt *memoryTransport) GetTx(ctx context.Context, request *coreClient.GetTxRequest) (*coreClient.TxResult, error) {
+	mt.Lock()
+	defer mt.Unlock()
+
+	txs, results := mt.txs[request.Round], mt.results[request.Round]
+	if request.Index >= uint32(len(txs)) || request.Index >= uint32(len(results)) {
+		return nil, fmt.Errorf("transaction not found")
+	}
+	return &coreClient.TxResult{
+		Block:  mt.blocks[request.Round],
+		Index:  request.Index,
+		Input:  txs[request.Index],
+		Output: results[request.Index],
+	}, nil
+}
+
+func (mt *memoryTransport) GetEvents(ctx context.Context, request *coreClient.GetEventsRequest) ([]*coreClient.Event, error) {
+	return mt.events[request.Round], nil
+}
+
+func (mt *memoryTransport) Query(ctx context.Context, request *coreClient.QueryRequest) (*coreClient.QueryResponse, error) {
+	if mt.queryHandler == nil {
+		return nil, fmt.Errorf("queries not supported")
+	}
+	rsp, err := mt.queryHandler(request.Round, request.Method, request.Args)
+	if err != nil {
+		return nil, err
+	}
+	return &coreClient.QueryResponse{Data: cbor.Marshal(rsp)}, nil
+}
+
+type nopSubscription struct{}
+
+func (nopSubscription) Close() {}
+
+func (mt *memoryTransport) WatchBlocks(ctx context.Context, runtimeID common.Namespace) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	if mt.blockCh == nil {
+		return nil, nil, fmt.Errorf("watching blocks not supported")
+	}
+	return mt.blockCh, nopSubscription{}, nil
+}
+
+func newMemoryTransport() *memoryTransport {
+	return &memoryTransport{
+		chainContext: "0000000000000000000000000000000000000000000000000000000000000001",
+		blocks:       make(map[uint64]*block.Block),
+		txs:          make(map[uint64][][]byte),
+		results:      make(map[uint64][][]byte),
+		events:       make(map[uint64][]*coreClient.Event),
+	}
+}
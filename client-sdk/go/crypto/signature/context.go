@@ -10,6 +10,12 @@ const chainContextSeparator = " for chain "
 // Context is the chain domain separation context.
 type Context string
 
+// New derives a signature context from the given base context by binding it to this chain
+// domain separation context.
+//
+// The resulting context has the form "<base> for chain <chain context>", for example:
+//
+//	oasis-runtime-sdk/tx: v0 for chain ca4842870b97a6d5c0d025adce0b6a0dec94d2ba192ede70f96349cfbe3628b9
 func (c Context) New(base []byte) []byte {
 	ctx := append([]byte{}, base...)
 	ctx = append(ctx, []byte(chainContextSeparator)...)
@@ -18,6 +24,10 @@ func (c Context) New(base []byte) []byte {
 }
 
 // DeriveChainContext derives the chain domain separation context for a given runtime.
+//
+// The chain context is the hex-encoded SHA-512/256 hash of the binary runtime identifier
+// followed by the consensus layer chain context. Tools signing transactions offline can use it
+// together with New to reproduce the signature context.
 func DeriveChainContext(runtimeID common.Namespace, consensusChainContext string) Context {
 	rawRuntimeID, _ := runtimeID.MarshalBinary()
 	return Context(hash.NewFromBytes(
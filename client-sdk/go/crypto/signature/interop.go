@@ -0,0 +1,13 @@
+package signature
+
+// VerifyTSSignature returns true iff the signature, as produced by a ContextSigner of the
+// TypeScript SDK, is valid for the public key over the message and the context derived from the
+// given base context and chain context.
+//
+// The context is derived independently of Context.New, the same way as combineChainContext of the
+// TypeScript SDK does it, so that tests can catch any drift in how the two SDKs derive signer
+// messages.
+func VerifyTSSignature(pk PublicKey, base string, chainContext Context, message, sig []byte) bool {
+	context := base + chainContextSeparator + string(chainContext)
+	return pk.Verify([]byte(context), message, sig)
+}
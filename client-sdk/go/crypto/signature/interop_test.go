@@ -0,0 +1,48 @@
+package signature_test
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// tsSignedTx is an accounts.Transfer transaction signed by an Ed25519 signer (seed of all 0x01
+// bytes) with signUnverifiedTransaction of the TypeScript SDK. It is the output of the
+// testdata/ts_signed_tx.js generator.
+const tsSignedTx = "8258b8a3617601626169a262736981a2656e6f6e6365076c616464726573735f73706563a1697369676e6174757265a1676564323535313958208a88e3dd7409f195fd52db2d3cba5d72ca6709bf1d94121bf3748801b40f6f5c63666565a2636761731907d066616d6f756e74824164406463616c6ca264626f6479a262746f5500c8d0f459db38e5cc31ca77e66d2c4456dcbeb50266616d6f756e74824203e840666d6574686f64716163636f756e74732e5472616e7366657281a1697369676e617475726558402966067bb04d02cc57fba3075db575ae542fea7d7bfef4d17ab0823ce0d43c2072385677e7371612776741a1e84496b96bffe598ee0b9a0a2be79cdf65caab0d"
+
+func TestVerifyTSSignature(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	chainCtx := signature.DeriveChainContext(runtimeID, "643fb06848be7e970af3b5b2d772eb8cfb30499c8162bc18ac03df2f5e22520e")
+
+	raw, err := hex.DecodeString(tsSignedTx)
+	require.NoError(err, "DecodeString")
+	var ut types.UnverifiedTransaction
+	require.NoError(cbor.Unmarshal(raw, &ut), "Unmarshal")
+	require.Len(ut.AuthProofs, 1)
+
+	// The transaction should verify with the context derived by the Go SDK.
+	tx, err := ut.Verify(chainCtx)
+	require.NoError(err, "Verify")
+	require.EqualValues("accounts.Transfer", tx.Call.Method)
+	require.EqualValues(7, tx.AuthInfo.SignerInfo[0].Nonce)
+	require.EqualValues(2000, tx.AuthInfo.Fee.Gas)
+
+	pk := tx.AuthInfo.SignerInfo[0].AddressSpec.Signature.PublicKey
+	sig := ut.AuthProofs[0].Signature
+	base := string(types.SignatureContextBase)
+	require.True(signature.VerifyTSSignature(pk, base, chainCtx, ut.Body, sig), "TS signature should verify")
+	require.False(signature.VerifyTSSignature(pk, base, chainCtx, []byte("tampered"), sig), "tampered message should not verify")
+	require.False(signature.VerifyTSSignature(pk, "oasis-runtime-sdk/tx: v1", chainCtx, ut.Body, sig), "different base context should not verify")
+	require.False(signature.VerifyTSSignature(pk, base, signature.DeriveChainContext(runtimeID, "other"), ut.Body, sig), "different chain context should not verify")
+}
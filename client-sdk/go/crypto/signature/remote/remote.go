@@ -0,0 +1,154 @@
+// Package remote provides a signer that delegates signing to a remote HTTP signing service.
+package remote
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+)
+
+// DefaultTimeout is the default timeout for signing requests.
+const DefaultTimeout = 10 * time.Second
+
+// SignRequest is the request sent to the remote signing service.
+type SignRequest struct {
+	// PublicKey is the public key of the key that should be used for signing.
+	PublicKey string `json:"public_key"`
+	// Context is the signature domain separation context.
+	Context []byte `json:"context"`
+	// Message is the message to be signed.
+	Message []byte `json:"message"`
+}
+
+// SignResponse is the response returned by the remote signing service.
+type SignResponse struct {
+	// Signature is the signature over the context and message.
+	Signature []byte `json:"signature"`
+}
+
+// Signer is a signer that delegates signing to a remote HTTP signing service.
+//
+// Each signing request is POSTed to the configured endpoint as a JSON-encoded SignRequest and the
+// service is expected to reply with a JSON-encoded SignResponse. Byte fields are base64-encoded.
+type Signer struct {
+	endpoint   string
+	publicKey  signature.PublicKey
+	authHeader string
+	timeout    time.Duration
+	client     *http.Client
+}
+
+// Option is an option for configuring the remote signer.
+type Option func(s *Signer)
+
+// WithTimeout configures the timeout for each signing request.
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *Signer) {
+		s.timeout = timeout
+	}
+}
+
+// WithAuthorization configures the value of the Authorization header sent with each signing
+// request (e.g. "Bearer <token>").
+func WithAuthorization(value string) Option {
+	return func(s *Signer) {
+		s.authHeader = value
+	}
+}
+
+// WithHTTPClient configures the HTTP client used for signing requests.
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *Signer) {
+		s.client = client
+	}
+}
+
+// Public returns the PublicKey corresponding to the signer.
+func (s *Signer) Public() signature.PublicKey {
+	return s.publicKey
+}
+
+// ContextSign generates a signature over the context and message by asking the remote signing
+// service to sign it.
+func (s *Signer) ContextSign(context, message []byte) ([]byte, error) {
+	body, err := json.Marshal(&SignRequest{
+		PublicKey: s.publicKey.String(),
+		Context:   context,
+		Message:   message,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := contextWithTimeout(s.timeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.authHeader != "" {
+		req.Header.Set("Authorization", s.authHeader)
+	}
+
+	rsp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: request failed: %w", err)
+	}
+	defer rsp.Body.Close()
+
+	if rsp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(rsp.Body, 1024))
+		return nil, fmt.Errorf("remote signer: unexpected status %d: %s", rsp.StatusCode, bytes.TrimSpace(msg))
+	}
+
+	var signRsp SignResponse
+	if err = json.NewDecoder(rsp.Body).Decode(&signRsp); err != nil {
+		return nil, fmt.Errorf("remote signer: malformed response: %w", err)
+	}
+	if len(signRsp.Signature) == 0 {
+		return nil, fmt.Errorf("remote signer: empty signature")
+	}
+	if !s.publicKey.Verify(context, message, signRsp.Signature) {
+		return nil, fmt.Errorf("remote signer: invalid signature")
+	}
+	return signRsp.Signature, nil
+}
+
+// String returns the string representation of the signer.
+func (s *Signer) String() string {
+	return fmt.Sprintf("remote signer %s (%s)", s.publicKey, s.endpoint)
+}
+
+// Reset is a no-op as the signer holds no sensitive state.
+func (s *Signer) Reset() {
+}
+
+func contextWithTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+// NewSigner creates a new remote signer for the key with the given public key, which signs by
+// POSTing signing requests to the given endpoint.
+func NewSigner(endpoint string, publicKey signature.PublicKey, opts ...Option) signature.Signer {
+	s := &Signer{
+		endpoint:  endpoint,
+		publicKey: publicKey,
+		timeout:   DefaultTimeout,
+		client:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
@@ -0,0 +1,83 @@
+package remote
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestRemoteSigner(t *testing.T) {
+	require := require.New(t)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+
+	// The key held by the signing service.
+	key := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-sdk/remote: test signer"))
+
+	tx := types.NewTransaction(nil, "accounts.Transfer", nil)
+	tx.AppendAuthSignature(key.Public(), 0)
+
+	// Precompute the expected signature.
+	expected := tx.PrepareForSigning()
+	require.NoError(expected.AppendSign(chainCtx, key), "AppendSign")
+	expectedSig := expected.UnverifiedTransaction().AuthProofs[0].Signature
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var req SignRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if req.PublicKey != key.Public().String() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(&SignResponse{Signature: expectedSig})
+	}))
+	defer server.Close()
+
+	signer := NewSigner(server.URL, key.Public(), WithAuthorization("Bearer secret"), WithTimeout(time.Second))
+	require.True(key.Public().Equal(signer.Public()))
+
+	ts := tx.PrepareForSigning()
+	err := ts.AppendSign(chainCtx, signer)
+	require.NoError(err, "AppendSign with remote signer")
+	require.EqualValues(1, requests)
+
+	utx := ts.UnverifiedTransaction()
+	require.EqualValues(expectedSig, utx.AuthProofs[0].Signature)
+	_, err = utx.Verify(chainCtx)
+	require.NoError(err, "Verify")
+
+	// Missing authorization should fail.
+	signer = NewSigner(server.URL, key.Public())
+	_, err = signer.ContextSign([]byte("test"), []byte("message"))
+	require.Error(err, "ContextSign should fail without authorization")
+	require.Contains(err.Error(), "unexpected status 401")
+
+	// Signatures that don't verify should be rejected.
+	signer = NewSigner(server.URL, key.Public(), WithAuthorization("Bearer secret"))
+	_, err = signer.ContextSign([]byte("test"), []byte("other message"))
+	require.Error(err, "ContextSign should fail on invalid signatures")
+	require.Contains(err.Error(), "invalid signature")
+}
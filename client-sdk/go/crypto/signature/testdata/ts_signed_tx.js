@@ -0,0 +1,41 @@
+// Generates the golden transaction used by TestVerifyTSSignature by signing it with
+// signUnverifiedTransaction of the TypeScript SDK and encoding it with its toCBOR.
+//
+// Usage (after npm ci in client-sdk/ts-web, so that the workspace packages are built and linked):
+//
+//	NODE_PATH=../../../../ts-web/node_modules node ts_signed_tx.js
+const oasis = require('@oasisprotocol/client');
+const oasisRT = require('@oasisprotocol/client-rt');
+
+const runtimeID = oasis.misc.fromHex('8000000000000000000000000000000000000000000000000000000000000000');
+const consensusChainContext = '643fb06848be7e970af3b5b2d772eb8cfb30499c8162bc18ac03df2f5e22520e';
+const signer = new oasis.signature.BlindContextSigner(
+    oasis.signature.NaclSigner.fromSeed(new Uint8Array(32).fill(1), 'this key is not important'),
+);
+
+(async function main() {
+    const transaction = {
+        v: oasisRT.transaction.LATEST_TRANSACTION_VERSION,
+        call: {
+            method: 'accounts.Transfer',
+            body: {
+                to: oasis.misc.fromHex('00c8d0f459db38e5cc31ca77e66d2c4456dcbeb502'),
+                amount: [oasis.quantity.fromBigInt(1000n), oasisRT.token.NATIVE_DENOMINATION],
+            },
+        },
+        ai: {
+            si: [{address_spec: {signature: {ed25519: signer.public()}}, nonce: 7}],
+            fee: {amount: [oasis.quantity.fromBigInt(100n), oasisRT.token.NATIVE_DENOMINATION], gas: 2000},
+        },
+    };
+    const utx = await oasisRT.transaction.signUnverifiedTransaction(
+        [signer],
+        runtimeID,
+        consensusChainContext,
+        transaction,
+    );
+    console.log(oasis.misc.toHex(oasis.misc.toCBOR(utx)));
+})().catch((e) => {
+    console.error(e);
+    process.exit(1);
+});
@@ -8,6 +8,13 @@ import (
 )
 
 const (
+	// ModuleName is the accounts module name.
+	ModuleName = "accounts"
+
+	// ErrInsufficientBalanceCode is the accounts module error code returned when an account does
+	// not have enough balance.
+	ErrInsufficientBalanceCode = 2
+
 	// Callable methods.
 	methodTransfer = "accounts.Transfer"
 
@@ -60,6 +67,10 @@ func (a *v1) Balances(ctx context.Context, round uint64, address types.Address)
 	return &balances, nil
 }
 
+func init() {
+	types.RegisterMethodBody(methodTransfer, Transfer{})
+}
+
 // NewV1 generates a V1 client helper for the accounts module.
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
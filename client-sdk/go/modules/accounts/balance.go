@@ -0,0 +1,45 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// ErrGasNotSet is the error returned by RequiredBalance when the transaction's gas limit has not
+// been set yet.
+var ErrGasNotSet = errors.New("transaction gas limit not set")
+
+// RequiredBalance returns the minimum balance (in the fee denomination) that the caller needs for
+// the given transaction to succeed. This is the fee amount plus, for accounts.Transfer calls in the
+// fee denomination, the transferred amount. Transfers in other denominations need an additional
+// balance in that denomination which is not included.
+//
+// The fee must be final, so the transaction's gas limit must already be set (e.g. estimated via
+// core.EstimateGas), otherwise ErrGasNotSet is returned.
+func RequiredBalance(ctx context.Context, tx *types.Transaction) (quantity.Quantity, error) {
+	if gas := tx.AuthInfo.Fee.Gas; gas == 0 || gas == client.GasAuto {
+		return quantity.Quantity{}, ErrGasNotSet
+	}
+
+	fee := tx.AuthInfo.Fee.Amount
+	required := fee.Amount.Clone()
+
+	if tx.Call.Method == methodTransfer {
+		var body Transfer
+		if err := types.DecodeBodyInto(tx.Call.Body, &body); err != nil {
+			return quantity.Quantity{}, err
+		}
+		if body.Amount.Denomination == fee.Denomination {
+			if err := required.Add(&body.Amount.Amount); err != nil {
+				return quantity.Quantity{}, fmt.Errorf("failed to compute required balance: %w", err)
+			}
+		}
+	}
+	return *required, nil
+}
@@ -0,0 +1,56 @@
+package accounts
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestRequiredBalance(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	fee := &types.Fee{
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+		Gas:    1000,
+	}
+
+	// Transfers in the fee denomination need the transferred amount and the fee.
+	tx := NewTransferTx(fee, &Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(100), types.NativeDenomination),
+	})
+	required, err := RequiredBalance(ctx, tx)
+	require.NoError(err, "RequiredBalance")
+	require.EqualValues(*quantity.NewFromUint64(110), required)
+	require.EqualValues(*quantity.NewFromUint64(10), fee.Amount.Amount, "fee amount should not be modified")
+
+	// Transfers in other denominations only need the fee.
+	tx = NewTransferTx(fee, &Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(100), types.Denomination("TEST")),
+	})
+	required, err = RequiredBalance(ctx, tx)
+	require.NoError(err, "RequiredBalance")
+	require.EqualValues(*quantity.NewFromUint64(10), required)
+
+	// Other methods only need the fee.
+	tx = types.NewTransaction(fee, "consensus.Deposit", nil)
+	required, err = RequiredBalance(ctx, tx)
+	require.NoError(err, "RequiredBalance")
+	require.EqualValues(*quantity.NewFromUint64(10), required)
+
+	// The fee is not final until the gas limit is set.
+	for _, gas := range []uint64{0, client.GasAuto} {
+		tx = types.NewTransaction(&types.Fee{Amount: fee.Amount, Gas: gas}, "consensus.Deposit", nil)
+		_, err = RequiredBalance(ctx, tx)
+		require.ErrorIs(err, ErrGasNotSet)
+	}
+}
@@ -0,0 +1,72 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// BatchError is the error returned by BatchBalances when querying some of the addresses failed.
+type BatchError struct {
+	// Errors are the query errors keyed by address string.
+	Errors map[string]error
+}
+
+// Error implements error.
+func (e *BatchError) Error() string {
+	addrs := make([]string, 0, len(e.Errors))
+	for addr := range e.Errors {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+
+	msgs := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		msgs = append(msgs, fmt.Sprintf("%s: %s", addr, e.Errors[addr]))
+	}
+	return fmt.Sprintf("failed to query balances of %d account(s): %s", len(addrs), strings.Join(msgs, "; "))
+}
+
+// BatchBalances queries the balances of the given accounts at the given round.
+//
+// The queries are issued concurrently and the result is keyed by address string. The number of
+// concurrent in-flight queries is only limited by the runtime client, so configure it with
+// client.WithConcurrency when querying many accounts. Failed queries don't fail the whole batch:
+// balances of the other accounts are still returned, together with a *BatchError describing the
+// failures.
+func BatchBalances(ctx context.Context, rc client.RuntimeClient, round uint64, addresses []types.Address) (map[string]*AccountBalances, error) {
+	var (
+		wg      sync.WaitGroup
+		lock    sync.Mutex
+		results = make(map[string]*AccountBalances, len(addresses))
+		errs    = make(map[string]error)
+	)
+	ac := NewV1(rc)
+	for _, addr := range addresses {
+		wg.Add(1)
+		go func(addr types.Address) {
+			defer wg.Done()
+
+			balances, err := ac.Balances(ctx, round, addr)
+
+			lock.Lock()
+			defer lock.Unlock()
+			if err != nil {
+				errs[addr.String()] = err
+				return
+			}
+			results[addr.String()] = balances
+		}(addr)
+	}
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return results, &BatchError{Errors: errs}
+	}
+	return results, nil
+}
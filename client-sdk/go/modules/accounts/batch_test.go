@@ -0,0 +1,72 @@
+package accounts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client/clienttest"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestBatchBalances(t *testing.T) {
+	require := require.New(t)
+
+	alice := sdkTesting.Alice.Address
+	bob := sdkTesting.Bob.Address
+	charlie := sdkTesting.Charlie.Address
+	amounts := map[types.Address]uint64{
+		alice:   100,
+		bob:     200,
+		charlie: 300,
+	}
+
+	f := clienttest.NewFixture(common.NewTestNamespaceFromSeed([]byte("oasis-sdk/accounts: test runtime"), 0))
+	for addr, amount := range amounts {
+		f.SetBalance(addr, nativeAmount(amount))
+	}
+	rc := f.Client(client.WithConcurrency(2))
+	ctx := context.Background()
+
+	results, err := accounts.BatchBalances(ctx, rc, 0, []types.Address{alice, bob, charlie})
+	require.NoError(err, "BatchBalances")
+	require.Len(results, 3)
+	for addr, amount := range amounts {
+		q := results[addr.String()].Balances[types.NativeDenomination]
+		require.EqualValues(amount, q.ToBigInt().Uint64())
+	}
+
+	// Failures should be reported without failing the whole batch.
+	f.RegisterQuery("accounts.Balances", func(state *clienttest.State, args cbor.RawMessage) (interface{}, error) {
+		var query accounts.BalancesQuery
+		if err := cbor.Unmarshal(args, &query); err != nil {
+			return nil, err
+		}
+		if query.Address == bob {
+			return nil, fmt.Errorf("account not found")
+		}
+		return &accounts.AccountBalances{
+			Balances: map[types.Denomination]types.Quantity{
+				types.NativeDenomination: state.Balance(query.Address, types.NativeDenomination),
+			},
+		}, nil
+	})
+	results, err = accounts.BatchBalances(ctx, rc, 0, []types.Address{alice, bob, charlie})
+	require.Error(err, "BatchBalances should report failed queries")
+	var batchErr *accounts.BatchError
+	require.True(errors.As(err, &batchErr))
+	require.Len(batchErr.Errors, 1)
+	require.Contains(batchErr.Errors, bob.String())
+	require.Len(results, 2)
+	require.Contains(results, alice.String())
+	require.Contains(results, charlie.String())
+}
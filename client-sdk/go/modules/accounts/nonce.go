@@ -0,0 +1,60 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// waitNoncePollInterval is the interval at which WaitNonce polls the nonce in case the node does
+// not support block subscriptions.
+var waitNoncePollInterval = time.Second
+
+// WaitNonce waits until the nonce of the given account is at least the given target.
+//
+// The nonce is checked on each new block via a block subscription and in case the node does not
+// support it, by periodically polling. Use the context to bound the time spent waiting.
+func WaitNonce(ctx context.Context, rc client.RuntimeClient, address types.Address, target uint64) error {
+	ac := NewV1(rc)
+
+	// Subscribe before checking the current nonce so that no blocks are missed.
+	annBlkCh, sub, err := rc.WatchBlocks(ctx)
+	if err != nil {
+		// Fall back to polling in case the node does not support block subscriptions.
+		annBlkCh = nil
+	} else {
+		defer sub.Close()
+	}
+
+	ticker := time.NewTicker(waitNoncePollInterval)
+	defer ticker.Stop()
+	var tickCh <-chan time.Time
+	if annBlkCh == nil {
+		tickCh = ticker.C
+	}
+
+	for {
+		nonce, err := ac.Nonce(ctx, client.RoundLatest, address)
+		if err != nil {
+			return fmt.Errorf("failed to query nonce: %w", err)
+		}
+		if nonce >= target {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case _, ok := <-annBlkCh:
+			if !ok {
+				// The subscription has been terminated, continue by polling.
+				annBlkCh = nil
+				tickCh = ticker.C
+			}
+		case <-tickCh:
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/pubsub"
+	roothash "github.com/oasisprotocol/oasis-core/go/roothash/api"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// nonceRuntimeClient is a runtime client that increments the nonce of the signer of each
+// submitted transaction.
+type nonceRuntimeClient struct {
+	client.RuntimeClient
+
+	sync.Mutex
+	nonces map[types.Address]uint64
+}
+
+func (rc *nonceRuntimeClient) SubmitTxNoWait(ctx context.Context, utx *types.UnverifiedTransaction) error {
+	var tx types.Transaction
+	if err := cbor.Unmarshal(utx.Body, &tx); err != nil {
+		return err
+	}
+	addr, err := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+	if err != nil {
+		return err
+	}
+
+	// Simulate the transaction being executed in a later block.
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		rc.Lock()
+		defer rc.Unlock()
+		rc.nonces[addr]++
+	}()
+	return nil
+}
+
+func (rc *nonceRuntimeClient) WatchBlocks(ctx context.Context) (<-chan *roothash.AnnotatedBlock, pubsub.ClosableSubscription, error) {
+	return nil, nil, fmt.Errorf("not supported")
+}
+
+func (rc *nonceRuntimeClient) Query(ctx context.Context, round uint64, method string, args, rsp interface{}) error {
+	if method != methodNonce {
+		return fmt.Errorf("unsupported query: %s", method)
+	}
+
+	rc.Lock()
+	defer rc.Unlock()
+	*rsp.(*uint64) = rc.nonces[args.(*NonceQuery).Address]
+	return nil
+}
+
+func TestWaitNonce(t *testing.T) {
+	require := require.New(t)
+
+	waitNoncePollInterval = 10 * time.Millisecond
+	defer func() { waitNoncePollInterval = time.Second }()
+
+	alice := sdkTesting.Alice.Address
+	rc := &nonceRuntimeClient{nonces: map[types.Address]uint64{alice: 5}}
+	ctx := context.Background()
+
+	// Reached targets should return immediately.
+	err := WaitNonce(ctx, rc, alice, 5)
+	require.NoError(err, "WaitNonce")
+
+	tx := NewTransferTx(nil, &Transfer{To: sdkTesting.Bob.Address})
+	tx.AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 5)
+	ts := tx.PrepareForSigning()
+	err = rc.SubmitTxNoWait(ctx, ts.UnverifiedTransaction())
+	require.NoError(err, "SubmitTxNoWait")
+
+	err = WaitNonce(ctx, rc, alice, 6)
+	require.NoError(err, "WaitNonce")
+
+	// The context should bound the time spent waiting.
+	waitCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	err = WaitNonce(waitCtx, rc, alice, 7)
+	require.ErrorIs(err, context.DeadlineExceeded)
+}
@@ -0,0 +1,49 @@
+package accounts_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client/clienttest"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+)
+
+func TestWaitNonceWatchBlocks(t *testing.T) {
+	require := require.New(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	f := clienttest.NewFixture(common.NewTestNamespaceFromSeed([]byte("oasis-sdk/accounts: test runtime"), 0))
+	f.SetBalance(sdkTesting.Alice.Address, nativeAmount(1000))
+	rc := f.Client()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- accounts.WaitNonce(ctx, rc, sdkTesting.Alice.Address, 1)
+	}()
+
+	// Give WaitNonce time to subscribe before the transaction is included in a block.
+	time.Sleep(100 * time.Millisecond)
+	tx := accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: nativeAmount(10),
+	})
+	tx.AppendAuthSignature(sdkTesting.Alice.Signer.Public(), 0)
+	ts := tx.PrepareForSigning()
+	require.NoError(ts.AppendSign(f.ChainContext(), sdkTesting.Alice.Signer), "AppendSign")
+	require.NoError(rc.SubmitTxNoWait(ctx, ts.UnverifiedTransaction()), "SubmitTxNoWait")
+
+	// The new block should wake up WaitNonce well before the next poll.
+	select {
+	case err := <-errCh:
+		require.NoError(err, "WaitNonce")
+	case <-time.After(500 * time.Millisecond):
+		require.Fail("WaitNonce should return once the block is announced")
+	}
+}
@@ -0,0 +1,38 @@
+package accounts
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// BatchTransfer performs the given transfers from the signer's account.
+//
+// The accounts module does not support multiple transfers in a single transaction, so each
+// transfer is submitted as a separate transaction with consecutive nonces starting at the
+// account's current nonce. Transfers are not atomic: they are executed in order and in case a
+// transfer fails, the error indicates its index and the remaining transfers are not submitted
+// while the preceding ones remain executed.
+func BatchTransfer(ctx context.Context, rc client.RuntimeClient, signer signature.Signer, transfers []Transfer) error {
+	ac := NewV1(rc)
+	nonce, err := ac.Nonce(ctx, client.RoundLatest, types.NewAddress(signer.Public()))
+	if err != nil {
+		return fmt.Errorf("failed to query nonce: %w", err)
+	}
+
+	for i, t := range transfers {
+		tb := ac.Transfer(t.To, t.Amount).
+			SetFeeGas(client.GasAuto).
+			AppendAuthSignature(signer.Public(), nonce+uint64(i))
+		if err = tb.AppendSign(ctx, signer); err != nil {
+			return fmt.Errorf("transfer %d: %w", i, err)
+		}
+		if err = tb.SubmitTx(ctx, nil); err != nil {
+			return fmt.Errorf("transfer %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package accounts_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client/clienttest"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func nativeAmount(amount uint64) types.BaseUnits {
+	return types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)
+}
+
+func TestBatchTransfer(t *testing.T) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	f := clienttest.NewFixture(common.NewTestNamespaceFromSeed([]byte("oasis-sdk/accounts: test runtime"), 0))
+	f.SetBalance(sdkTesting.Alice.Address, nativeAmount(1000))
+	rc := f.Client()
+
+	recipients := []types.Address{sdkTesting.Bob.Address, sdkTesting.Charlie.Address, sdkTesting.Dave.Address}
+	var transfers []accounts.Transfer
+	for i, to := range recipients {
+		transfers = append(transfers, accounts.Transfer{To: to, Amount: nativeAmount(uint64(100 * (i + 1)))})
+	}
+	err := accounts.BatchTransfer(ctx, rc, sdkTesting.Alice.Signer, transfers)
+	require.NoError(err, "BatchTransfer")
+
+	for i, to := range recipients {
+		require.EqualValues(*quantity.NewFromUint64(uint64(100 * (i + 1))), f.Balance(to, types.NativeDenomination))
+	}
+	require.EqualValues(*quantity.NewFromUint64(400), f.Balance(sdkTesting.Alice.Address, types.NativeDenomination))
+	nonce, err := accounts.NewV1(rc).Nonce(ctx, client.RoundLatest, sdkTesting.Alice.Address)
+	require.NoError(err, "Nonce")
+	require.EqualValues(3, nonce)
+
+	// Failed transfers should stop the batch.
+	transfers = []accounts.Transfer{
+		{To: sdkTesting.Bob.Address, Amount: nativeAmount(1000)},
+		{To: sdkTesting.Charlie.Address, Amount: nativeAmount(100)},
+	}
+	err = accounts.BatchTransfer(ctx, rc, sdkTesting.Alice.Signer, transfers)
+	require.Error(err, "BatchTransfer should fail with insufficient balance")
+	require.Contains(err.Error(), "transfer 0")
+	require.EqualValues(*quantity.NewFromUint64(200), f.Balance(sdkTesting.Charlie.Address, types.NativeDenomination))
+}
@@ -72,6 +72,11 @@ func (a *v1) ConsensusAccount(ctx context.Context, round uint64, query *AccountQ
 	return &account, nil
 }
 
+func init() {
+	types.RegisterMethodBody(methodDeposit, Deposit{})
+	types.RegisterMethodBody(methodWithdraw, Withdraw{})
+}
+
 // NewV1 generates a V1 client helper for the consensus accounts module.
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
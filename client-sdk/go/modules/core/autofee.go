@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var (
+	// ErrNoFeeDenomination is the error returned by SubmitAutoFee when the signer does not hold
+	// enough of any of the candidate fee denominations.
+	ErrNoFeeDenomination = errors.New("no fee denomination with sufficient balance")
+
+	// ErrInsufficientBalance is the error returned by SubmitAutoFee when the signer does not hold
+	// enough of the denomination being transferred.
+	ErrInsufficientBalance = errors.New("insufficient balance")
+)
+
+// SubmitAutoFee signs and submits the given unsigned transaction, paying the fee with the first of
+// the given candidate fee amounts that the signer's account holds enough of.
+//
+// The runtime does not advertise which fee denominations it accepts, so the candidates must be
+// provided by the caller in order of preference. For accounts.Transfer calls the signer must also
+// hold enough of the transferred denomination. The signer is appended to a copy of the transaction
+// with its current nonce and, in case no gas limit is set or it is set to client.GasAuto, the gas
+// is estimated before the fee is selected.
+func SubmitAutoFee(ctx context.Context, rc client.RuntimeClient, signer signature.Signer, tx *types.Transaction, fees []types.BaseUnits) (cbor.RawMessage, error) {
+	address := types.NewAddress(signer.Public())
+	balances, err := accounts.NewV1(rc).Balances(ctx, client.RoundLatest, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query balances: %w", err)
+	}
+
+	// Transfers in the fee denomination are accounted for by RequiredBalance, but the balance for
+	// any other denomination needs to be checked separately.
+	if body, err := types.DecodeBody(tx); err == nil {
+		if transfer, ok := body.(*accounts.Transfer); ok {
+			balance := balances.Balances[transfer.Amount.Denomination]
+			if balance.Cmp(&transfer.Amount.Amount) < 0 {
+				return nil, fmt.Errorf("%w (account: %s, denomination: %s)", ErrInsufficientBalance, address, transfer.Amount.Denomination)
+			}
+		}
+	}
+
+	ptx, err := appendSigner(ctx, rc, types.AddressSpec{Signature: &types.PublicKey{PublicKey: signer.Public()}}, tx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		candidate types.Transaction
+		found     bool
+	)
+	for _, fee := range fees {
+		candidate = *ptx
+		candidate.AuthInfo.Fee.Amount = fee
+		required, err := accounts.RequiredBalance(ctx, &candidate)
+		if err != nil {
+			return nil, err
+		}
+		balance := balances.Balances[fee.Denomination]
+		if balance.Cmp(&required) >= 0 {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("%w (account: %s)", ErrNoFeeDenomination, address)
+	}
+
+	utx, err := signTx(ctx, rc, signer, &candidate)
+	if err != nil {
+		return nil, err
+	}
+	return rc.SubmitTx(ctx, utx)
+}
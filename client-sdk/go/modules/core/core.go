@@ -2,12 +2,25 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"time"
+
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
 )
 
 const (
+	// ModuleName is the core module name.
+	ModuleName = "core"
+
+	// ErrInvalidMethodCode is the core module error code returned for calls to unknown methods.
+	ErrInvalidMethodCode = 3
+
+	// Queries.
 	methodEstimateGas = "core.EstimateGas"
 )
 
@@ -32,3 +45,79 @@ func (a *v1) EstimateGas(ctx context.Context, round uint64, tx *types.Transactio
 func NewV1(rc client.RuntimeClient) V1 {
 	return &v1{rc: rc}
 }
+
+// prepareTx appends the given address specification with the account's current nonce to a copy
+// of the given transaction and signs it with the given signer. In case the transaction has no gas
+// limit set or it is set to client.GasAuto, the gas is estimated before signing.
+func prepareTx(ctx context.Context, rc client.RuntimeClient, signer signature.Signer, addressSpec types.AddressSpec, tx *types.Transaction) (*types.UnverifiedTransaction, error) {
+	ptx, err := appendSigner(ctx, rc, addressSpec, tx)
+	if err != nil {
+		return nil, err
+	}
+	return signTx(ctx, rc, signer, ptx)
+}
+
+// appendSigner returns a copy of the given transaction with the given address specification and
+// the account's current nonce appended. In case the transaction has no gas limit set or it is set
+// to client.GasAuto, the gas is estimated.
+func appendSigner(ctx context.Context, rc client.RuntimeClient, addressSpec types.AddressSpec, tx *types.Transaction) (*types.Transaction, error) {
+	address, err := addressSpec.Address()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := accounts.NewV1(rc).Nonce(ctx, client.RoundLatest, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query nonce: %w", err)
+	}
+
+	ptx := *tx
+	ptx.AuthInfo.SignerInfo = append([]types.SignerInfo{}, tx.AuthInfo.SignerInfo...)
+	ptx.AppendSignerInfo(addressSpec, nonce)
+
+	if ptx.AuthInfo.Fee.Gas == 0 || ptx.AuthInfo.Fee.Gas == client.GasAuto {
+		// The transaction is simulated with the maximum gas limit so that it does not run out.
+		ptx.AuthInfo.Fee.Gas = client.GasAuto
+		gas, err := NewV1(rc).EstimateGas(ctx, client.RoundLatest, &ptx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to estimate gas: %w", err)
+		}
+		ptx.AuthInfo.Fee.Gas = gas
+	}
+	return &ptx, nil
+}
+
+// signTx signs the given transaction with the given signer.
+func signTx(ctx context.Context, rc client.RuntimeClient, signer signature.Signer, tx *types.Transaction) (*types.UnverifiedTransaction, error) {
+	rtInfo, err := rc.GetInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve runtime info: %w", err)
+	}
+	ts := tx.PrepareForSigning()
+	if err = ts.AppendSign(rtInfo.ChainContext, signer); err != nil {
+		return nil, fmt.Errorf("failed to sign transaction: %w", err)
+	}
+	return ts.UnverifiedTransaction(), nil
+}
+
+// Ping checks that the runtime accepts and executes transactions end-to-end by signing and
+// submitting a minimal transaction (a zero-amount transfer from the signer's account to itself)
+// and waiting for its result.
+//
+// It returns the time it took for the transaction to be executed.
+func Ping(ctx context.Context, rc client.RuntimeClient, signer signature.Signer) (time.Duration, error) {
+	address := types.NewAddress(signer.Public())
+	tx := accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(0), types.NativeDenomination),
+	})
+	utx, err := prepareTx(ctx, rc, signer, types.AddressSpec{Signature: &types.PublicKey{PublicKey: signer.Public()}}, tx)
+	if err != nil {
+		return 0, fmt.Errorf("ping: %w", err)
+	}
+
+	start := time.Now()
+	if _, err = rc.SubmitTx(ctx, utx); err != nil {
+		return 0, fmt.Errorf("ping: failed to submit transaction: %w", err)
+	}
+	return time.Since(start), nil
+}
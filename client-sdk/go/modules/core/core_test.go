@@ -0,0 +1,205 @@
+package core_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client/clienttest"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/accounts"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/modules/core"
+	sdkTesting "github.com/oasisprotocol/oasis-sdk/client-sdk/go/testing"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+var testRuntimeID = common.NewTestNamespaceFromSeed([]byte("oasis-sdk/core: test runtime"), 0)
+
+// latestTx returns the latest round of the fixture and the transaction included in it.
+func latestTx(t *testing.T, f *clienttest.Fixture, rc client.RuntimeClient) (uint64, *types.Transaction) {
+	require := require.New(t)
+
+	ctx := context.Background()
+	blk, err := rc.GetBlock(ctx, client.RoundLatest)
+	require.NoError(err, "GetBlock")
+	if blk.Header.Round == 0 {
+		return 0, nil
+	}
+	txs, err := rc.GetTransactions(ctx, blk.Header.Round)
+	require.NoError(err, "GetTransactions")
+	require.Len(txs, 1)
+	tx, err := txs[0].Verify(f.ChainContext())
+	require.NoError(err, "Verify")
+	return blk.Header.Round, tx
+}
+
+func TestPing(t *testing.T) {
+	require := require.New(t)
+
+	f := clienttest.NewFixture(testRuntimeID)
+	rc := f.Client()
+
+	latency, err := core.Ping(context.Background(), rc, sdkTesting.Alice.Signer)
+	require.NoError(err, "Ping")
+	require.Greater(int64(latency), int64(0), "latency should be positive")
+
+	round, tx := latestTx(t, f, rc)
+	require.EqualValues(1, round)
+	require.EqualValues("accounts.Transfer", tx.Call.Method)
+	require.EqualValues(1000, tx.AuthInfo.Fee.Gas)
+	require.EqualValues(0, tx.AuthInfo.SignerInfo[0].Nonce)
+}
+
+func TestSubmitAutoFee(t *testing.T) {
+	require := require.New(t)
+
+	f := clienttest.NewFixture(testRuntimeID)
+	f.SetBalance(sdkTesting.Alice.Address, types.NewBaseUnits(*quantity.NewFromUint64(100), "FOO"))
+	rc := f.Client()
+	signer := sdkTesting.Alice.Signer
+	ctx := context.Background()
+
+	fees := []types.BaseUnits{
+		types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+		types.NewBaseUnits(*quantity.NewFromUint64(20), "FOO"),
+	}
+
+	// The account only holds the second denomination, so it should be used for the fee.
+	tx := accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(50), "FOO"),
+	})
+	_, err := core.SubmitAutoFee(ctx, rc, signer, tx, fees)
+	require.NoError(err, "SubmitAutoFee")
+	round, submitted := latestTx(t, f, rc)
+	require.EqualValues(1, round)
+	require.EqualValues("FOO", submitted.AuthInfo.Fee.Amount.Denomination)
+	require.EqualValues(1000, submitted.AuthInfo.Fee.Gas)
+	require.EqualValues(0, submitted.AuthInfo.SignerInfo[0].Nonce)
+
+	// The transferred amount counts towards the required balance (50 FOO are left).
+	tx = accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(40), "FOO"),
+	})
+	_, err = core.SubmitAutoFee(ctx, rc, signer, tx, fees)
+	require.Error(err, "SubmitAutoFee should fail without sufficient balance")
+	require.ErrorIs(err, core.ErrNoFeeDenomination)
+	round, _ = latestTx(t, f, rc)
+	require.EqualValues(1, round, "nothing should be submitted")
+	require.True(tx.AuthInfo.Fee.Amount.Amount.IsZero(), "caller's fee should not be modified")
+	require.Empty(tx.AuthInfo.SignerInfo, "caller's signer info should not be modified")
+
+	// Transfers in a denomination other than the fee's need a balance in that denomination.
+	tx = accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+	})
+	_, err = core.SubmitAutoFee(ctx, rc, signer, tx, fees[1:])
+	require.Error(err, "SubmitAutoFee should fail without sufficient transfer balance")
+	require.ErrorIs(err, core.ErrInsufficientBalance)
+	round, _ = latestTx(t, f, rc)
+	require.EqualValues(1, round, "nothing should be submitted")
+
+	// Successful submissions should not modify the caller's transaction either.
+	tx = accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), "FOO"),
+	})
+	_, err = core.SubmitAutoFee(ctx, rc, signer, tx, fees)
+	require.NoError(err, "SubmitAutoFee")
+	round, _ = latestTx(t, f, rc)
+	require.EqualValues(2, round)
+	require.True(tx.AuthInfo.Fee.Amount.Amount.IsZero(), "caller's fee should not be modified")
+	require.EqualValues(0, tx.AuthInfo.Fee.Gas, "caller's gas should not be modified")
+	require.Empty(tx.AuthInfo.SignerInfo, "caller's signer info should not be modified")
+}
+
+func TestSignAndSubmitTxAs(t *testing.T) {
+	require := require.New(t)
+
+	signer := sdkTesting.Alice.Signer
+	other := sdkTesting.Bob.Signer
+	config := &types.MultisigConfig{
+		Signers: []types.MultisigSigner{
+			{PublicKey: types.PublicKey{PublicKey: signer.Public()}, Weight: 1},
+			{PublicKey: types.PublicKey{PublicKey: other.Public()}, Weight: 1},
+		},
+		Threshold: 1,
+	}
+	multisigAddr := types.NewAddressFromMultisig(config)
+
+	f := clienttest.NewFixture(testRuntimeID)
+	f.SetBalance(multisigAddr, types.NewBaseUnits(*quantity.NewFromUint64(100), types.NativeDenomination))
+	var nonceQueries int
+	f.RegisterQuery("accounts.Nonce", func(state *clienttest.State, args cbor.RawMessage) (interface{}, error) {
+		var query accounts.NonceQuery
+		if err := cbor.Unmarshal(args, &query); err != nil {
+			return nil, err
+		}
+		nonceQueries++
+		return state.Nonce(query.Address), nil
+	})
+	rc := f.Client()
+	ctx := context.Background()
+
+	// Sign with one of the members of a multisig account.
+	tx := accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+	})
+	_, err := core.SignAndSubmitTxAs(ctx, rc, signer, types.AddressSpec{Multisig: config}, tx)
+	require.NoError(err, "SignAndSubmitTxAs")
+
+	round, submitted := latestTx(t, f, rc)
+	require.EqualValues(1, round)
+	require.Len(submitted.AuthInfo.SignerInfo, 1)
+	si := submitted.AuthInfo.SignerInfo[0]
+	require.Nil(si.AddressSpec.Signature, "signer info should not use the signing key")
+	require.NotNil(si.AddressSpec.Multisig)
+	addr, err := si.AddressSpec.Address()
+	require.NoError(err, "Address")
+	require.EqualValues(multisigAddr, addr)
+	require.EqualValues(0, si.Nonce)
+	require.EqualValues(1000, submitted.AuthInfo.Fee.Gas)
+	require.EqualValues(*quantity.NewFromUint64(90), f.Balance(multisigAddr, types.NativeDenomination))
+
+	// GasAuto should be estimated the same way as an unset gas limit.
+	tx = accounts.NewTransferTx(&types.Fee{Gas: client.GasAuto}, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+	})
+	_, err = core.SignAndSubmitTxAs(ctx, rc, signer, types.AddressSpec{Multisig: config}, tx)
+	require.NoError(err, "SignAndSubmitTxAs")
+	round, submitted = latestTx(t, f, rc)
+	require.EqualValues(2, round)
+	require.EqualValues(1000, submitted.AuthInfo.Fee.Gas)
+
+	// Signers that are not part of the address specification should be rejected before making
+	// any queries.
+	nonceQueries = 0
+	tx = accounts.NewTransferTx(nil, &accounts.Transfer{
+		To:     sdkTesting.Bob.Address,
+		Amount: types.NewBaseUnits(*quantity.NewFromUint64(10), types.NativeDenomination),
+	})
+	spec := types.AddressSpec{Signature: &types.PublicKey{PublicKey: other.Public()}}
+	_, err = core.SignAndSubmitTxAs(ctx, rc, signer, spec, tx)
+	require.Error(err, "SignAndSubmitTxAs should fail for unrelated signers")
+	require.Zero(nonceQueries, "nothing should be queried")
+	require.Empty(tx.AuthInfo.SignerInfo, "caller's signer info should not be modified")
+
+	// Multisig members whose weight does not satisfy the threshold should be rejected as well.
+	config.Threshold = 2
+	_, err = core.SignAndSubmitTxAs(ctx, rc, signer, types.AddressSpec{Multisig: config}, tx)
+	require.Error(err, "SignAndSubmitTxAs should fail for insufficient weight")
+	require.Contains(err.Error(), "threshold")
+	require.Zero(nonceQueries, "nothing should be queried")
+	require.Empty(tx.AuthInfo.SignerInfo, "caller's signer info should not be modified")
+	round, _ = latestTx(t, f, rc)
+	require.EqualValues(2, round, "nothing should be submitted")
+}
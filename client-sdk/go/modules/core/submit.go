@@ -0,0 +1,61 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+// SignAndSubmitTxAs signs and submits the given unsigned transaction on behalf of the account
+// identified by the given address specification, which may differ from the signing key (e.g. a
+// multisig account with the signer being one of its members).
+//
+// The address specification is appended to a copy of the transaction with the account's current
+// nonce and, in case no gas limit is set, the gas is estimated before signing. The signer must be
+// part of the address specification and, for multisig accounts, its weight must satisfy the
+// threshold.
+func SignAndSubmitTxAs(ctx context.Context, rc client.RuntimeClient, signer signature.Signer, addressSpec types.AddressSpec, tx *types.Transaction) (cbor.RawMessage, error) {
+	if err := checkSigner(signer, addressSpec); err != nil {
+		return nil, err
+	}
+	utx, err := prepareTx(ctx, rc, signer, addressSpec, tx)
+	if err != nil {
+		return nil, err
+	}
+	return rc.SubmitTx(ctx, utx)
+}
+
+// checkSigner checks that the given signer alone can authenticate for the given address
+// specification.
+func checkSigner(signer signature.Signer, addressSpec types.AddressSpec) error {
+	pk := signer.Public()
+	switch {
+	case addressSpec.Signature != nil:
+		if !addressSpec.Signature.PublicKey.Equal(pk) {
+			return fmt.Errorf("signer %s is not part of the address specification", pk)
+		}
+		return nil
+	case addressSpec.Multisig != nil:
+		config := addressSpec.Multisig
+		if err := config.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid multisig configuration: %w", err)
+		}
+		for _, ms := range config.Signers {
+			if !ms.PublicKey.Equal(pk) {
+				continue
+			}
+			if ms.Weight < config.Threshold {
+				return fmt.Errorf("signer %s weight %d does not satisfy multisig threshold %d", pk, ms.Weight, config.Threshold)
+			}
+			return nil
+		}
+		return fmt.Errorf("signer %s is not part of the multisig configuration", pk)
+	default:
+		return fmt.Errorf("malformed address specification")
+	}
+}
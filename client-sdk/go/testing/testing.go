@@ -15,13 +15,28 @@ import (
 type TestKey struct {
 	Signer  signature.Signer
 	Address types.Address
+
+	privateKey []byte
+}
+
+// ExportPrivateKey returns the raw private key of the test key so that it can be imported into
+// external tooling (e.g. other SDKs) used in integration tests.
+//
+// For Ed25519 keys this is the 32-byte RFC 8032 seed and for Secp256k1 keys this is the 32-byte
+// private scalar.
+//
+// Test keys are derived from public seeds and MUST only be used for testing.
+func (tk TestKey) ExportPrivateKey() []byte {
+	return append([]byte{}, tk.privateKey...)
 }
 
 func newEd25519TestKey(seed string) TestKey {
+	sk := sha512.Sum512_256([]byte(seed))
 	signer := ed25519.WrapSigner(memorySigner.NewTestSigner(seed))
 	return TestKey{
-		Signer:  signer,
-		Address: types.NewAddress(signer.Public()),
+		Signer:     signer,
+		Address:    types.NewAddress(signer.Public()),
+		privateKey: sk[:],
 	}
 }
 
@@ -29,8 +44,9 @@ func newSecp256k1TestKey(seed string) TestKey {
 	pk := sha512.Sum512_256([]byte(seed))
 	signer := secp256k1.NewSigner(pk[:])
 	return TestKey{
-		Signer:  signer,
-		Address: types.NewAddress(signer.Public()),
+		Signer:     signer,
+		Address:    types.NewAddress(signer.Public()),
+		privateKey: pk[:],
 	}
 }
 
@@ -3,6 +3,13 @@ package testing
 import (
 	"fmt"
 	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
 )
 
 func TestPrintTestKeys(t *testing.T) {
@@ -11,3 +18,22 @@ func TestPrintTestKeys(t *testing.T) {
 	fmt.Printf("C: %v\n", Charlie.Signer.Public().String())
 	fmt.Printf("D: %v\n", Dave.Signer.Public().String())
 }
+
+func TestExportPrivateKey(t *testing.T) {
+	require := require.New(t)
+
+	for _, tk := range []TestKey{Alice, Bob, Charlie} {
+		coreSigner, err := memorySigner.NewFromSeed(tk.ExportPrivateKey())
+		require.NoError(err, "NewFromSeed")
+		signer := ed25519.WrapSigner(coreSigner)
+		require.True(signer.Public().Equal(tk.Signer.Public()), "exported key should re-import to the same signer")
+	}
+
+	signer := secp256k1.NewSigner(Dave.ExportPrivateKey())
+	require.True(signer.Public().Equal(Dave.Signer.Public()), "exported key should re-import to the same signer")
+
+	// Modifying the exported key should not affect the test key.
+	sk := Dave.ExportPrivateKey()
+	sk[0] ^= 0xff
+	require.NotEqual(sk, Dave.ExportPrivateKey())
+}
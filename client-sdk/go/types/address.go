@@ -5,6 +5,7 @@ import (
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	"github.com/oasisprotocol/oasis-core/go/common/crypto/address"
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
 	"github.com/oasisprotocol/oasis-core/go/common/encoding/bech32"
 	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
 
@@ -62,6 +63,14 @@ func (a Address) Equal(cmp Address) bool {
 	return (address.Address)(a).Equal((address.Address)(cmp))
 }
 
+// ConsensusAddress converts the address into a consensus layer address.
+//
+// Addresses derived from Ed25519 public keys use the same derivation in the runtime and in the
+// consensus layer, so a consensus account and its runtime account share the address.
+func (a Address) ConsensusAddress() staking.Address {
+	return (staking.Address)(a)
+}
+
 // String returns the string representation of an address.
 func (a Address) String() string {
 	bech32Addr, err := bech32.Encode(AddressBech32HRP.String(), a[:])
@@ -108,3 +117,17 @@ func NewAddressFromBech32(data string) (a Address) {
 func NewAddressFromMultisig(config *MultisigConfig) Address {
 	return (Address)(address.NewAddress(AddressV0MultisigContext, cbor.Marshal(config)))
 }
+
+// NewAddressFromConsensus creates a new address from the given consensus layer address.
+func NewAddressFromConsensus(addr staking.Address) Address {
+	return (Address)(addr)
+}
+
+// NewAddressFromConsensusPublicKey creates a new address from the given consensus layer
+// (Ed25519) public key.
+//
+// This is the address that is credited in the runtime when depositing from the consensus layer
+// account owned by the given public key.
+func NewAddressFromConsensusPublicKey(pk coreSignature.PublicKey) Address {
+	return NewAddress(ed25519.PublicKey(pk))
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	coreSignature "github.com/oasisprotocol/oasis-core/go/common/crypto/signature"
+	staking "github.com/oasisprotocol/oasis-core/go/staking/api"
+
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 )
 
@@ -17,6 +20,21 @@ func TestAddressEd25519(t *testing.T) {
 	require.EqualValues("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz", addr.String())
 }
 
+func TestAddressConsensus(t *testing.T) {
+	require := require.New(t)
+
+	var pk coreSignature.PublicKey
+	err := pk.UnmarshalText([]byte("utrdHlX///////////////////////////////////8="))
+	require.NoError(err, "UnmarshalText")
+
+	addr := NewAddressFromConsensusPublicKey(pk)
+	require.EqualValues("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz", addr.String())
+
+	consensusAddr := staking.NewAddress(pk)
+	require.EqualValues(consensusAddr, addr.ConsensusAddress())
+	require.True(addr.Equal(NewAddressFromConsensus(consensusAddr)))
+}
+
 func TestAddressSecp256k1(t *testing.T) {
 	/*
 			#[test]
@@ -0,0 +1,89 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+)
+
+// EncodeBody serializes the given method call body.
+//
+// In addition to serialization it makes sure that the serialized body deserializes back into the
+// same type without loss so that fields that cannot be serialized are caught before the body is
+// sent to the runtime.
+func EncodeBody(body interface{}) (cbor.RawMessage, error) {
+	var buf bytes.Buffer
+	if err := cbor.NewEncoder(&buf).Encode(body); err != nil {
+		return nil, fmt.Errorf("body: failed to serialize: %w", err)
+	}
+	raw := buf.Bytes()
+
+	if body != nil {
+		typ := reflect.TypeOf(body)
+		for typ.Kind() == reflect.Ptr {
+			typ = typ.Elem()
+		}
+		dec := reflect.New(typ).Interface()
+		if err := DecodeBodyInto(raw, dec); err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(cbor.Marshal(dec), raw) {
+			return nil, fmt.Errorf("body: serialization does not round-trip")
+		}
+	}
+	return raw, nil
+}
+
+// DecodeBodyInto deserializes the given method call body into dst.
+func DecodeBodyInto(raw cbor.RawMessage, dst interface{}) error {
+	if err := cbor.Unmarshal(raw, dst); err != nil {
+		return fmt.Errorf("body: failed to deserialize: %w", err)
+	}
+	return nil
+}
+
+var (
+	methodBodiesLock sync.RWMutex
+	methodBodies     = make(map[string]reflect.Type)
+)
+
+// RegisterMethodBody registers the body type of the given method so that bodies of transactions
+// calling the method can be decoded via DecodeBody. The proto argument is an instance (or a
+// pointer to an instance) of the body type.
+//
+// Modules register the body types of their methods when their client packages are imported.
+// This function panics if the method has already been registered.
+func RegisterMethodBody(method string, proto interface{}) {
+	typ := reflect.TypeOf(proto)
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+
+	methodBodiesLock.Lock()
+	defer methodBodiesLock.Unlock()
+
+	if _, exists := methodBodies[method]; exists {
+		panic(fmt.Sprintf("body: method '%s' is already registered", method))
+	}
+	methodBodies[method] = typ
+}
+
+// DecodeBody deserializes the body of the given transaction into the type registered for the
+// called method via RegisterMethodBody. A pointer to the decoded body is returned.
+func DecodeBody(tx *Transaction) (interface{}, error) {
+	methodBodiesLock.RLock()
+	typ, ok := methodBodies[tx.Call.Method]
+	methodBodiesLock.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("body: no body type registered for method '%s'", tx.Call.Method)
+	}
+
+	body := reflect.New(typ).Interface()
+	if err := DecodeBodyInto(tx.Call.Body, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
+)
+
+type lossyBody struct {
+	Value string
+}
+
+func (lb lossyBody) MarshalCBOR() ([]byte, error) {
+	return cbor.Marshal(lb.Value), nil
+}
+
+func (lb *lossyBody) UnmarshalCBOR(data []byte) error {
+	// Drop the value on purpose.
+	return nil
+}
+
+func TestEncodeBody(t *testing.T) {
+	require := require.New(t)
+
+	type transfer struct {
+		To     Address   `json:"to"`
+		Amount BaseUnits `json:"amount"`
+		Data   []byte    `json:"data,omitempty"`
+	}
+	body := transfer{
+		To:     NewAddressFromBech32("oasis1qryqqccycvckcxp453tflalujvlf78xymcdqw4vz"),
+		Amount: NewBaseUnits(*quantity.NewFromUint64(1000), NativeDenomination),
+		Data:   []byte("hello world"),
+	}
+
+	raw, err := EncodeBody(&body)
+	require.NoError(err, "EncodeBody")
+	require.EqualValues(NewTransaction(nil, "hello.World", &body).Call.Body, raw)
+
+	var dec transfer
+	err = DecodeBodyInto(raw, &dec)
+	require.NoError(err, "DecodeBodyInto")
+	require.EqualValues(body, dec, "body should round-trip")
+
+	raw, err = EncodeBody(nil)
+	require.NoError(err, "EncodeBody of a nil body")
+	require.EqualValues(cbor.Marshal(nil), raw)
+
+	// Unserializable bodies should be rejected.
+	_, err = EncodeBody(struct {
+		Ch chan int
+	}{make(chan int)})
+	require.Error(err, "EncodeBody should fail for unserializable bodies")
+
+	// Bodies that don't round-trip should be rejected.
+	_, err = EncodeBody(&lossyBody{Value: "hello"})
+	require.Error(err, "EncodeBody should fail for bodies that don't round-trip")
+
+	err = DecodeBodyInto([]byte{0xff}, &dec)
+	require.Error(err, "DecodeBodyInto should fail for malformed bodies")
+}
+
+type evmCallTx struct {
+	Address []byte    `json:"address"`
+	Value   BaseUnits `json:"value"`
+	Data    []byte    `json:"data"`
+}
+
+func init() {
+	RegisterMethodBody("evm.Call", (*evmCallTx)(nil))
+}
+
+func TestDecodeBody(t *testing.T) {
+	require := require.New(t)
+
+	require.Panics(func() { RegisterMethodBody("evm.Call", evmCallTx{}) }, "duplicate registration should panic")
+
+	call := evmCallTx{
+		Address: []byte("contract address"),
+		Value:   NewBaseUnits(*quantity.NewFromUint64(0), NativeDenomination),
+		Data:    []byte("calldata"),
+	}
+	tx := NewTransaction(nil, "evm.Call", &call)
+
+	body, err := DecodeBody(tx)
+	require.NoError(err, "DecodeBody")
+	require.IsType(&evmCallTx{}, body)
+	require.EqualValues(&call, body)
+
+	tx = NewTransaction(nil, "unknown.Method", &call)
+	_, err = DecodeBody(tx)
+	require.Error(err, "DecodeBody should fail for unregistered methods")
+}
@@ -38,11 +38,11 @@ func (pk *PublicKey) unmarshal(spk *serializedPublicKey) error {
 
 	switch {
 	case spk.Ed25519 != nil:
-		pk.PublicKey = spk.Ed25519
+		pk.PublicKey = *spk.Ed25519
 	case spk.Secp256k1 != nil:
-		pk.PublicKey = spk.Secp256k1
+		pk.PublicKey = *spk.Secp256k1
 	case spk.Sr25519 != nil:
-		pk.PublicKey = spk.Sr25519
+		pk.PublicKey = *spk.Sr25519
 	default:
 		return fmt.Errorf("unsupported public key type")
 	}
@@ -4,8 +4,14 @@ import (
 	"math"
 	"testing"
 
-	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
 	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/sr25519"
 )
 
 func TestMultisigConfigValidateBasic(t *testing.T) {
@@ -153,3 +159,28 @@ func TestMultisigConfigBatch(t *testing.T) {
 	_, _, err = config.Batch([][]byte{dummySigA, dummySigB, nil, nil})
 	require.Error(err, "too many signature slots")
 }
+
+func TestPublicKeyCBORRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	seed := make([]byte, 32)
+	seed[0] = 1
+	sr25519Signer, err := sr25519.NewSigner(append(seed, make([]byte, 32)...))
+	require.NoError(err, "sr25519.NewSigner")
+
+	for _, pk := range []signature.PublicKey{
+		ed25519.NewPublicKey("CgAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA="),
+		secp256k1.NewSigner(seed).Public(),
+		sr25519Signer.Public(),
+	} {
+		var decoded PublicKey
+		err = cbor.Unmarshal(cbor.Marshal(&PublicKey{PublicKey: pk}), &decoded)
+		require.NoError(err, "Unmarshal")
+
+		// Decoded keys should have the same concrete type as the original ones so that they can
+		// be used for deriving addresses.
+		require.IsType(pk, decoded.PublicKey)
+		require.True(pk.Equal(decoded.PublicKey), "decoded key should equal the original one")
+		require.EqualValues(NewAddress(pk), NewAddress(decoded.PublicKey))
+	}
+}
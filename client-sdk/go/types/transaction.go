@@ -5,9 +5,11 @@ import (
 	"fmt"
 
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	"github.com/oasisprotocol/oasis-core/go/common/crypto/hash"
 	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/secp256k1"
 )
 
 // SignatureContextBase is the transaction signature domain separation context base.
@@ -32,6 +34,14 @@ type UnverifiedTransaction struct {
 	AuthProofs []AuthProof
 }
 
+// Hash returns the hash of the serialized unverified transaction.
+//
+// This is the same hash that identifies the transaction in runtime blocks and events, so it can
+// be computed before submission and used to check whether the transaction has been included.
+func (ut *UnverifiedTransaction) Hash() hash.Hash {
+	return hash.NewFromBytes(cbor.Marshal(ut))
+}
+
 // Verify verifies and deserializes the unverified transaction.
 func (ut *UnverifiedTransaction) Verify(ctx signature.Context) (*Transaction, error) {
 	// Deserialize the inner body.
@@ -208,6 +218,39 @@ func NewTransaction(fee *Fee, method string, body interface{}) *Transaction {
 	return tx
 }
 
+// maxSignatureSize returns the maximum size of a signature produced by the given public key.
+func maxSignatureSize(pk signature.PublicKey) int {
+	switch pk.(type) {
+	case secp256k1.PublicKey:
+		// DER-encoded ECDSA signature.
+		return 72
+	default:
+		// Ed25519 and Sr25519 signatures.
+		return 64
+	}
+}
+
+// EstimateTxSize estimates the size (in bytes) of the serialized transaction after it has been
+// signed by all of the signers specified in its AuthInfo.
+func EstimateTxSize(tx *Transaction) int {
+	ut := UnverifiedTransaction{
+		Body:       cbor.Marshal(tx),
+		AuthProofs: make([]AuthProof, len(tx.AuthInfo.SignerInfo)),
+	}
+	for i, si := range tx.AuthInfo.SignerInfo {
+		switch {
+		case si.AddressSpec.Signature != nil:
+			ut.AuthProofs[i].Signature = make([]byte, maxSignatureSize(si.AddressSpec.Signature.PublicKey))
+		case si.AddressSpec.Multisig != nil:
+			ut.AuthProofs[i].Multisig = make([][]byte, len(si.AddressSpec.Multisig.Signers))
+			for j, mss := range si.AddressSpec.Multisig.Signers {
+				ut.AuthProofs[i].Multisig[j] = make([]byte, maxSignatureSize(mss.PublicKey.PublicKey))
+			}
+		}
+	}
+	return len(cbor.Marshal(&ut))
+}
+
 // Call is a method call.
 type Call struct {
 	Method string          `json:"method"`
@@ -226,6 +269,22 @@ type Fee struct {
 	Gas    uint64    `json:"gas"`
 }
 
+// GasPrice returns the fee amount paid per unit of gas, rounded down. The price is denominated in
+// the fee amount's denomination.
+//
+// Same as in the runtime, the gas price of a fee with zero gas is zero.
+func (f *Fee) GasPrice() (quantity.Quantity, error) {
+	if f.Gas == 0 {
+		return *quantity.NewQuantity(), nil
+	}
+
+	price := f.Amount.Amount.Clone()
+	if err := price.Quo(quantity.NewFromUint64(f.Gas)); err != nil {
+		return quantity.Quantity{}, fmt.Errorf("fee: failed to compute gas price: %w", err)
+	}
+	return *price, nil
+}
+
 // AddressSpec is common information that specifies an address as well as how to authenticate.
 type AddressSpec struct {
 	// Signature is for signature authentication.
@@ -238,7 +297,7 @@ type AddressSpec struct {
 func (as *AddressSpec) Address() (Address, error) {
 	switch {
 	case as.Signature != nil:
-		return NewAddress(as.Signature), nil
+		return NewAddress(as.Signature.PublicKey), nil
 	case as.Multisig != nil:
 		return NewAddressFromMultisig(as.Multisig), nil
 	default:
@@ -8,6 +8,7 @@ import (
 	"github.com/oasisprotocol/oasis-core/go/common"
 	"github.com/oasisprotocol/oasis-core/go/common/cbor"
 	memorySigner "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+	"github.com/oasisprotocol/oasis-core/go/common/quantity"
 
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature"
 	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
@@ -76,4 +77,104 @@ func TestTransactionSigning(t *testing.T) {
 	require.NoError(err, "Verify")
 	err = tx.ValidateBasic()
 	require.NoError(err, "ValidateBasic")
+
+	// Signer addresses should be derivable from the deserialized transaction.
+	addr, err := tx.AuthInfo.SignerInfo[0].AddressSpec.Address()
+	require.NoError(err, "Address")
+	require.EqualValues(NewAddress(signer.Public()), addr)
+	addr, err = tx.AuthInfo.SignerInfo[1].AddressSpec.Address()
+	require.NoError(err, "Address")
+	require.EqualValues(NewAddress(signer2.Public()), addr)
+}
+
+func TestTransactionVerifyTampered(t *testing.T) {
+	require := require.New(t)
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: tx signing"))
+
+	tx := NewTransaction(nil, "hello.World", nil)
+	tx.AppendAuthSignature(signer.Public(), 42)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+
+	ts := tx.PrepareForSigning()
+	err := ts.AppendSign(chainCtx, signer)
+	require.NoError(err, "AppendSign")
+
+	ut := ts.UnverifiedTransaction()
+	_, err = ut.Verify(chainCtx)
+	require.NoError(err, "Verify")
+
+	// Verification under a different chain context should fail.
+	otherChainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000002")
+	_, err = ut.Verify(otherChainCtx)
+	require.Error(err, "Verify should fail with a different chain context")
+
+	// Verification of a tampered body should fail.
+	tx.Call.Method = "hello.Tampered"
+	tampered := UnverifiedTransaction{
+		Body:       cbor.Marshal(tx),
+		AuthProofs: ut.AuthProofs,
+	}
+	_, err = tampered.Verify(chainCtx)
+	require.Error(err, "Verify should fail for a tampered body")
+}
+
+func TestEstimateTxSize(t *testing.T) {
+	require := require.New(t)
+
+	signer := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: tx signing"))
+	signer2 := ed25519.WrapSigner(memorySigner.NewTestSigner("oasis-runtime-sdk/test-keys: tx signing 2"))
+
+	tx := NewTransaction(nil, "hello.World", make([]byte, 1024))
+	tx.AppendAuthSignature(signer.Public(), 42)
+	tx.AppendAuthMultisig(&MultisigConfig{
+		Signers: []MultisigSigner{
+			{PublicKey: PublicKey{PublicKey: signer.Public()}, Weight: 1},
+			{PublicKey: PublicKey{PublicKey: signer2.Public()}, Weight: 1},
+		},
+		Threshold: 2,
+	}, 43)
+
+	var runtimeID common.Namespace
+	_ = runtimeID.UnmarshalHex("8000000000000000000000000000000000000000000000000000000000000000")
+	chainCtx := signature.DeriveChainContext(runtimeID, "0000000000000000000000000000000000000000000000000000000000000001")
+
+	ts := tx.PrepareForSigning()
+	err := ts.AppendSign(chainCtx, signer)
+	require.NoError(err, "AppendSign")
+	err = ts.AppendSign(chainCtx, signer2)
+	require.NoError(err, "AppendSign signer2")
+
+	size := len(cbor.Marshal(ts.UnverifiedTransaction()))
+	require.EqualValues(size, EstimateTxSize(tx), "estimated size should match the signed size")
+}
+
+func TestFeeGasPrice(t *testing.T) {
+	require := require.New(t)
+
+	fee := Fee{
+		Amount: NewBaseUnits(*quantity.NewFromUint64(10_050), NativeDenomination),
+		Gas:    1_000,
+	}
+	price, err := fee.GasPrice()
+	require.NoError(err, "GasPrice")
+	require.EqualValues(10, price.ToBigInt().Uint64(), "gas price should be rounded down")
+	require.EqualValues(10_050, fee.Amount.Amount.ToBigInt().Uint64(), "fee amount should not be modified")
+
+	// Amounts exceeding 64 bits should be handled.
+	var amount quantity.Quantity
+	require.NoError(amount.UnmarshalText([]byte("340282366920938463463374607431768211456"))) // 2^128
+	fee = Fee{Amount: NewBaseUnits(amount, NativeDenomination), Gas: 1<<64 - 1}
+	price, err = fee.GasPrice()
+	require.NoError(err, "GasPrice")
+	require.EqualValues("18446744073709551617", price.String()) // 2^64 + 1
+
+	fee = Fee{Amount: NewBaseUnits(*quantity.NewFromUint64(10), NativeDenomination)}
+	price, err = fee.GasPrice()
+	require.NoError(err, "GasPrice")
+	require.True(price.IsZero(), "gas price should be zero for zero gas")
 }
@@ -26,5 +26,6 @@ require (
 	github.com/oasisprotocol/curve25519-voi v0.0.0-20210716083614-f38f8e8b0b84 // indirect
 	github.com/oasisprotocol/oasis-core/go v0.2102.5
 	github.com/oasisprotocol/oasis-sdk/client-sdk/go v0.0.0-20210328195842-4de788c1c6f7
+	github.com/stretchr/testify v1.7.0
 	google.golang.org/grpc v1.38.0
 )
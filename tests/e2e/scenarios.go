@@ -22,6 +22,7 @@ var (
 		KVBalanceTest,
 		KVTransferTest,
 		KVDaveTest,
+		KVTransferFailTest,
 		KVMultisigTest,
 		KVRewardsTest,
 		KVTxGenTest,
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto"
+	"errors"
 	"fmt"
 	"math/rand"
 	"time"
@@ -72,6 +73,26 @@ func GetChainContext(ctx context.Context, rtc client.RuntimeClient) (signature.C
 	return info.ChainContext, nil
 }
 
+// MustFailWith checks that the given error is a failed call result emitted by
+// the given module with the given error code.
+//
+// Returns nil if this is the case and a descriptive error otherwise, so it can
+// be used in tests that expect a transaction to fail.
+func MustFailWith(err error, module string, code uint32) error {
+	if err == nil {
+		return fmt.Errorf("expected failure (module: %s code: %d), but got success", module, code)
+	}
+
+	var failed *types.FailedCallResult
+	if !errors.As(err, &failed) {
+		return fmt.Errorf("expected failure (module: %s code: %d), but got: %w", module, code, err)
+	}
+	if failed.Module != module || failed.Code != code {
+		return fmt.Errorf("expected failure (module: %s code: %d), but got: %w", module, code, failed)
+	}
+	return nil
+}
+
 // kvInsert inserts given key-value pair into storage.
 func kvInsert(rtc client.RuntimeClient, signer signature.Signer, key, value []byte) error {
 	ctx := context.Background()
@@ -377,52 +398,59 @@ func KVBalanceTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientCo
 	return nil
 }
 
-// KVTransferTest does a transfer test and verifies balances.
-func KVTransferTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error { // nolint: dupl
-	ctx := context.Background()
-	ac := accounts.NewV1(rtc)
-
-	nonce, err := ac.Nonce(ctx, client.RoundLatest, testing.Alice.Address)
+// checkNativeBalance checks that the given account has the expected balance
+// in the native denomination.
+func checkNativeBalance(ctx context.Context, ac accounts.V1, name string, address types.Address, expected uint64) error {
+	b, err := ac.Balances(ctx, client.RoundLatest, address)
 	if err != nil {
 		return err
 	}
-
-	log.Info("transferring 100 units from Alice to Bob")
-	tb := ac.Transfer(testing.Bob.Address, types.NewBaseUnits(*quantity.NewFromUint64(100), types.NativeDenomination)).
-		SetFeeGas(defaultGasAmount).
-		AppendAuthSignature(testing.Alice.Signer.Public(), nonce)
-	_ = tb.AppendSign(ctx, testing.Alice.Signer)
-	if err = tb.SubmitTx(ctx, nil); err != nil {
-		return err
+	q, ok := b.Balances[types.NativeDenomination]
+	if !ok {
+		return fmt.Errorf("%s's account is missing native denomination balance", name)
 	}
-
-	log.Info("checking Alice's account balance")
-	ab, err := ac.Balances(ctx, client.RoundLatest, testing.Alice.Address)
-	if err != nil {
-		return err
-	}
-	if q, ok := ab.Balances[types.NativeDenomination]; ok {
-		if q.Cmp(quantity.NewFromUint64(10002900)) != 0 {
-			return fmt.Errorf("Alice's account balance is wrong (expected 10002900, got %s)", q.String()) //nolint: stylecheck
-		}
-	} else {
-		return fmt.Errorf("Alice's account is missing native denomination balance") //nolint: stylecheck
+	if q.Cmp(quantity.NewFromUint64(expected)) != 0 {
+		return fmt.Errorf("%s's account balance is wrong (expected %d, got %s)", name, expected, q.String())
 	}
+	return nil
+}
 
-	log.Info("checking Bob's account balance")
-	bb, err := ac.Balances(ctx, client.RoundLatest, testing.Bob.Address)
-	if err != nil {
-		return err
-	}
-	if q, ok := bb.Balances[types.NativeDenomination]; ok {
-		if q.Cmp(quantity.NewFromUint64(2100)) != 0 {
-			return fmt.Errorf("Bob's account balance is wrong (expected 2100, got %s)", q.String()) //nolint: stylecheck
-		}
-	} else {
-		return fmt.Errorf("Bob's account is missing native denomination balance") //nolint: stylecheck
-	}
+// KVTransferTest does a transfer test and verifies balances.
+func KVTransferTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
+	ctx := context.Background()
+	ac := accounts.NewV1(rtc)
 
-	return nil
+	return RunSteps(ctx, log, []Step{
+		{
+			Name: "transfer 100 units from Alice to Bob",
+			Execute: func(ctx context.Context) (*client.ExecutionResult, error) {
+				nonce, err := ac.Nonce(ctx, client.RoundLatest, testing.Alice.Address)
+				if err != nil {
+					return nil, err
+				}
+
+				tb := ac.Transfer(testing.Bob.Address, types.NewBaseUnits(*quantity.NewFromUint64(100), types.NativeDenomination)).
+					SetFeeGas(defaultGasAmount).
+					AppendAuthSignature(testing.Alice.Signer.Public(), nonce)
+				if err = tb.AppendSign(ctx, testing.Alice.Signer); err != nil {
+					return nil, err
+				}
+				return tb.Execute(ctx)
+			},
+		},
+		{
+			Name: "check Alice's account balance",
+			Check: func(ctx context.Context, _ *client.ExecutionResult) error {
+				return checkNativeBalance(ctx, ac, "Alice", testing.Alice.Address, 10002900)
+			},
+		},
+		{
+			Name: "check Bob's account balance",
+			Check: func(ctx context.Context, _ *client.ExecutionResult) error {
+				return checkNativeBalance(ctx, ac, "Bob", testing.Bob.Address, 2100)
+			},
+		},
+	})
 }
 
 // KVDaveTest does a tx signing test using the secp256k1 signer.
@@ -473,6 +501,42 @@ func KVDaveTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn,
 	return nil
 }
 
+// KVTransferFailTest checks that a transfer exceeding the account balance and a
+// transfer running out of gas both fail without changing the balance.
+func KVTransferFailTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
+	ctx := context.Background()
+	ac := accounts.NewV1(rtc)
+
+	transfer := func(amount, gas uint64) error {
+		nonce, err := ac.Nonce(ctx, client.RoundLatest, testing.Charlie.Address)
+		if err != nil {
+			return err
+		}
+
+		tb := ac.Transfer(testing.Bob.Address, types.NewBaseUnits(*quantity.NewFromUint64(amount), types.NativeDenomination)).
+			SetFeeGas(gas).
+			AppendAuthSignature(testing.Charlie.Signer.Public(), nonce)
+		if err = tb.AppendSign(ctx, testing.Charlie.Signer); err != nil {
+			return fmt.Errorf("failed to sign transaction: %w", err)
+		}
+		return tb.SubmitTx(ctx, nil)
+	}
+
+	log.Info("transferring 1000000 units from Charlie to Bob should fail")
+	if err := MustFailWith(transfer(1000000, defaultGasAmount), "accounts", 2); err != nil {
+		return err
+	}
+
+	// The transfer costs 100 gas in the simple-keyvalue runtime.
+	log.Info("transferring 10 units from Charlie to Bob with insufficient gas should fail")
+	if err := MustFailWith(transfer(10, 50), "core", 12); err != nil {
+		return err
+	}
+
+	log.Info("checking Charlie's account balance")
+	return checkNativeBalance(ctx, ac, "Charlie", testing.Charlie.Address, 1000)
+}
+
 func KVMultisigTest(sc *RuntimeScenario, log *logging.Logger, conn *grpc.ClientConn, rtc client.RuntimeClient) error {
 	signerA := testing.Alice.Signer
 	signerB := testing.Bob.Signer
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oasisprotocol/oasis-core/go/common/logging"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client"
+)
+
+// Step is a single step of a multi-step test.
+type Step struct {
+	// Name is a human readable description of the step.
+	Name string
+
+	// Execute submits the transaction for this step (optional).
+	Execute func(ctx context.Context) (*client.ExecutionResult, error)
+
+	// Check verifies the outcome of this step (optional). The execution result is nil in case
+	// the step does not execute a transaction.
+	Check func(ctx context.Context, res *client.ExecutionResult) error
+}
+
+// RunSteps runs the given test steps in order, stopping at the first step that fails.
+//
+// The returned error identifies the failed step and, if the step executed a transaction, the
+// transaction hash and the round in which it was included.
+func RunSteps(ctx context.Context, log *logging.Logger, steps []Step) error {
+	for i, step := range steps {
+		log.Info("running step", "step", i, "name", step.Name)
+
+		var (
+			res *client.ExecutionResult
+			err error
+		)
+		if step.Execute != nil {
+			if res, err = step.Execute(ctx); err != nil {
+				return fmt.Errorf("step %d (%s): execution failed: %w", i, step.Name, err)
+			}
+		}
+		if step.Check != nil {
+			if err = step.Check(ctx, res); err != nil {
+				if res != nil {
+					return fmt.Errorf("step %d (%s): check failed (tx: %s round: %d): %w", i, step.Name, res.TxHash, res.Round, err)
+				}
+				return fmt.Errorf("step %d (%s): check failed: %w", i, step.Name, err)
+			}
+		}
+	}
+	return nil
+}
@@ -29,6 +29,12 @@ import (
 
 const highGasAmount = 1000000
 
+// GasDefaults are the default gas limits keyed by method name. They are used by SignAndSubmitTx
+// for transactions that don't specify an explicit gas limit instead of estimating gas.
+//
+// The map should only be modified before any transactions are generated.
+var GasDefaults = map[string]uint64{}
+
 // AccountType is the type of account to create.
 type AccountType uint8
 
@@ -92,8 +98,22 @@ func CheckInvariants(ctx context.Context, rtc client.RuntimeClient) error {
 	return nil
 }
 
+// resolveGas sets the gas limit of the given transaction, unless it has been explicitly set.
+// The default from GasDefaults is used if configured for the method, otherwise gas is estimated.
+func resolveGas(ctx context.Context, rtc client.RuntimeClient, tx types.Transaction) types.Transaction {
+	if tx.AuthInfo.Fee.Gas != 0 {
+		return tx
+	}
+	if gas, ok := GasDefaults[tx.Call.Method]; ok {
+		tx.AuthInfo.Fee.Gas = gas
+		return tx
+	}
+	return EstimateGas(ctx, rtc, tx)
+}
+
 // SignAndSubmitTx signs and submits the given transaction.
-// Gas estimation is done automatically.
+// An explicitly set gas limit is used as-is, otherwise the default from GasDefaults is used if
+// configured for the method and gas estimation is done automatically as a last resort.
 func SignAndSubmitTx(ctx context.Context, rtc client.RuntimeClient, signer signature.Signer, tx types.Transaction) error {
 	// Get chain context.
 	chainCtx, err := GetChainContext(ctx, rtc)
@@ -109,8 +129,8 @@ func SignAndSubmitTx(ctx context.Context, rtc client.RuntimeClient, signer signa
 	}
 	tx.AppendAuthSignature(signer.Public(), nonce)
 
-	// Estimate gas.
-	etx := EstimateGas(ctx, rtc, tx)
+	// Determine the gas limit.
+	etx := resolveGas(ctx, rtc, tx)
 
 	// Sign the transaction.
 	stx := etx.PrepareForSigning()
@@ -0,0 +1,65 @@
+package txgen
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/oasisprotocol/oasis-core/go/common"
+	"github.com/oasisprotocol/oasis-core/go/common/cbor"
+	coreMemSig "github.com/oasisprotocol/oasis-core/go/common/crypto/signature/signers/memory"
+
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/client/clienttest"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/crypto/signature/ed25519"
+	"github.com/oasisprotocol/oasis-sdk/client-sdk/go/types"
+)
+
+func TestSignAndSubmitTxGasDefaults(t *testing.T) {
+	require := require.New(t)
+
+	f := clienttest.NewFixture(common.NewTestNamespaceFromSeed([]byte("oasis-sdk/txgen: test runtime"), 0))
+	var estimated int
+	f.RegisterQuery("core.EstimateGas", func(state *clienttest.State, args cbor.RawMessage) (interface{}, error) {
+		estimated++
+		return uint64(1000), nil
+	})
+	nop := func(state *clienttest.State, caller types.Address, tx *types.Transaction) (interface{}, error) {
+		return nil, nil
+	}
+	f.RegisterTx("evm.Call", nop)
+	f.RegisterTx("evm.Create", nop)
+	rc := f.Client()
+	ctx := context.Background()
+	signer := ed25519.WrapSigner(coreMemSig.NewTestSigner("oasis-sdk/txgen: test signer"))
+
+	submittedGas := func(round uint64) uint64 {
+		txs, err := rc.GetTransactions(ctx, round)
+		require.NoError(err, "GetTransactions")
+		require.Len(txs, 1)
+		tx, err := txs[0].Verify(f.ChainContext())
+		require.NoError(err, "Verify")
+		return tx.AuthInfo.Fee.Gas
+	}
+
+	GasDefaults["evm.Call"] = 25000
+	defer delete(GasDefaults, "evm.Call")
+
+	// Transactions without explicit gas should use the configured default.
+	err := SignAndSubmitTx(ctx, rc, signer, *types.NewTransaction(nil, "evm.Call", nil))
+	require.NoError(err, "SignAndSubmitTx")
+	require.EqualValues(25000, submittedGas(1))
+	require.Zero(estimated, "gas should not be estimated when a default is configured")
+
+	// Explicit gas should override the default.
+	tx := types.NewTransaction(&types.Fee{Gas: 50000}, "evm.Call", nil)
+	err = SignAndSubmitTx(ctx, rc, signer, *tx)
+	require.NoError(err, "SignAndSubmitTx")
+	require.EqualValues(50000, submittedGas(2))
+
+	// Methods without a default should fall back to gas estimation.
+	err = SignAndSubmitTx(ctx, rc, signer, *types.NewTransaction(nil, "evm.Create", nil))
+	require.NoError(err, "SignAndSubmitTx")
+	require.EqualValues(1000, submittedGas(3))
+	require.EqualValues(1, estimated)
+}